@@ -0,0 +1,188 @@
+package verifiers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is error of verifier which not called because its circuit breaker is open,
+// it always counted as failure even with custom error comparator
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is state of circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed function called and results recorded in rolling window
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen function not called, ErrCircuitOpen returned immediately
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen limited amount of trial calls allowed to check is function recovered
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// breakerBuckets is amount of buckets in rolling window
+const breakerBuckets = 10
+
+// BreakerConfig configure circuit breaker, zero values replaced by defaults
+type BreakerConfig struct {
+	// Window is duration of rolling window with results of calls, 1 minute by default.
+	// Window shorter than 10 nanoseconds extended to 10 nanoseconds, so every bucket of window not empty
+	Window time.Duration
+	// MinCalls is minimal amount of calls in window to open circuit, 10 by default
+	MinCalls int
+	// FailureRate is rate of failed calls in window to open circuit, 0.5 by default
+	FailureRate float64
+	// OpenTimeout is how long circuit open before trial calls, 30 seconds by default
+	OpenTimeout time.Duration
+	// HalfOpenCalls is amount of trial calls which should succeed to close circuit, 1 by default
+	HalfOpenCalls int
+	// OnStateChange called on every change of state
+	OnStateChange func(from CircuitState, to CircuitState)
+}
+
+type breakerBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+type breaker struct {
+	cfg BreakerConfig
+	fn  Verifier
+
+	mu       sync.Mutex
+	state    CircuitState
+	openedAt time.Time
+	// trials is amount of started trial calls in half-open state, succeeded is amount of finished without error
+	trials    int
+	succeeded int
+	buckets   [breakerBuckets]breakerBucket
+}
+
+// Breaker return verifier which stop calling fn when rate of failures in rolling window reach threshold,
+// name and labels of fn kept. Calls interrupted by context not recorded
+func Breaker(fn Verifier, cfg BreakerConfig) Verifier {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.Window < breakerBuckets {
+		cfg.Window = breakerBuckets
+	}
+	if cfg.MinCalls <= 0 {
+		cfg.MinCalls = 10
+	}
+	if cfg.FailureRate <= 0 {
+		cfg.FailureRate = 0.5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = time.Second * 30
+	}
+	if cfg.HalfOpenCalls <= 0 {
+		cfg.HalfOpenCalls = 1
+	}
+	b := &breaker{cfg: cfg, fn: fn, state: CircuitClosed}
+	name, labels := Describe(fn)
+	return Named(name, b.verify, labels...)
+}
+
+func (b *breaker) verify(ctx context.Context) error {
+	if !b.allow() {
+		return ErrCircuitOpen
+	}
+	err := b.fn(ctx)
+	if ctx.Err() != nil {
+		b.abort()
+		return err
+	}
+	b.record(err != nil)
+	return err
+}
+
+// allow return true if function can be called, open circuit become half-open after timeout
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	from := b.state
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cfg.OpenTimeout {
+		b.state, b.trials, b.succeeded = CircuitHalfOpen, 0, 0
+	}
+	allowed := true
+	switch b.state {
+	case CircuitOpen:
+		allowed = false
+	case CircuitHalfOpen:
+		if b.trials < b.cfg.HalfOpenCalls {
+			b.trials += 1
+		} else {
+			allowed = false
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	if from != to {
+		b.transition(from, to)
+	}
+	return allowed
+}
+
+// abort release trial call which result not recorded
+func (b *breaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen && b.trials > 0 {
+		b.trials -= 1
+	}
+}
+
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case CircuitHalfOpen:
+		if failed {
+			b.state, b.openedAt = CircuitOpen, time.Now()
+			break
+		}
+		b.succeeded += 1
+		if b.succeeded >= b.cfg.HalfOpenCalls {
+			b.state, b.buckets = CircuitClosed, [breakerBuckets]breakerBucket{}
+		}
+	case CircuitClosed:
+		now := time.Now()
+		width := b.cfg.Window / breakerBuckets
+		start := now.Truncate(width)
+		bucket := &b.buckets[int(now.UnixNano()/int64(width))%breakerBuckets]
+		if !bucket.start.Equal(start) {
+			*bucket = breakerBucket{start: start}
+		}
+		if failed {
+			bucket.failures += 1
+		} else {
+			bucket.successes += 1
+		}
+
+		successes, failures := 0, 0
+		for _, bucket := range b.buckets {
+			if now.Sub(bucket.start) < b.cfg.Window {
+				successes, failures = successes+bucket.successes, failures+bucket.failures
+			}
+		}
+		total := successes + failures
+		if total >= b.cfg.MinCalls && float64(failures)/float64(total) >= b.cfg.FailureRate {
+			b.state, b.openedAt = CircuitOpen, now
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	if from != to {
+		b.transition(from, to)
+	}
+}
+
+func (b *breaker) transition(from CircuitState, to CircuitState) {
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,138 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type transitions struct {
+	mu     sync.Mutex
+	states []verifiers.CircuitState
+}
+
+func (t *transitions) record(from verifiers.CircuitState, to verifiers.CircuitState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.states = append(t.states, to)
+}
+
+func (t *transitions) get() []verifiers.CircuitState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]verifiers.CircuitState{}, t.states...)
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("Return: ErrCircuitOpen - circuit opened by failures and closed by trial", func(t *testing.T) {
+		var calls int32
+		var healthy int32
+		states := &transitions{}
+		fn := verifiers.Breaker(verifiers.Named("db", func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			if atomic.LoadInt32(&healthy) == 1 {
+				return nil
+			}
+			return someError
+		}), verifiers.BreakerConfig{MinCalls: 4, FailureRate: 0.75, OpenTimeout: time.Millisecond * 50, OnStateChange: states.record})
+		name, _ := verifiers.Describe(fn)
+		assert.Equal(t, "db", name)
+
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, someError, fn(context.Background()))
+		}
+		assert.Equal(t, verifiers.ErrCircuitOpen, fn(context.Background()))
+		assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+		// Failed trial open circuit again
+		time.Sleep(time.Millisecond * 60)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, verifiers.ErrCircuitOpen, fn(context.Background()))
+
+		time.Sleep(time.Millisecond * 60)
+		atomic.StoreInt32(&healthy, 1)
+		assert.NoError(t, fn(context.Background()))
+		assert.NoError(t, fn(context.Background()))
+		assert.Equal(t, int32(7), atomic.LoadInt32(&calls))
+		assert.Equal(t, []verifiers.CircuitState{
+			verifiers.CircuitOpen,
+			verifiers.CircuitHalfOpen,
+			verifiers.CircuitOpen,
+			verifiers.CircuitHalfOpen,
+			verifiers.CircuitClosed,
+		}, states.get())
+	})
+	t.Run("Return: nil - rate below threshold or old failures out of window", func(t *testing.T) {
+		fn := verifiers.Breaker(sequence("-+-+"), verifiers.BreakerConfig{MinCalls: 4, FailureRate: 0.6})
+		for i := 0; i < 4; i++ {
+			_ = fn(context.Background())
+		}
+		assert.NoError(t, fn(context.Background()))
+
+		fn = verifiers.Breaker(sequence("-"), verifiers.BreakerConfig{MinCalls: 2, Window: time.Millisecond * 100})
+		assert.Equal(t, someError, fn(context.Background()))
+		time.Sleep(time.Millisecond * 150)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, verifiers.ErrCircuitOpen, fn(context.Background()))
+	})
+	t.Run("Return: err - window shorter than buckets", func(t *testing.T) {
+		fn := verifiers.Breaker(fail, verifiers.BreakerConfig{Window: time.Nanosecond * 5, MinCalls: 100})
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, someError, fn(context.Background()))
+		}
+	})
+	t.Run("Return: ErrCircuitOpen - trial calls limited in half-open state", func(t *testing.T) {
+		release := make(chan struct{})
+		var calls int32
+		fn := verifiers.Breaker(func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return someError
+			}
+			<-release
+			return nil
+		}, verifiers.BreakerConfig{MinCalls: 1, OpenTimeout: time.Millisecond * 10, HalfOpenCalls: 2})
+		assert.Equal(t, someError, fn(context.Background()))
+		time.Sleep(time.Millisecond * 20)
+		results := make(chan error, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				results <- fn(context.Background())
+			}()
+		}
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&calls) == 3
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, verifiers.ErrCircuitOpen, fn(context.Background()))
+		close(release)
+		assert.NoError(t, <-results)
+		assert.NoError(t, <-results)
+		assert.NoError(t, fn(context.Background()))
+	})
+	t.Run("Return: nil - interrupted calls not recorded", func(t *testing.T) {
+		fn := verifiers.Breaker(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, verifiers.BreakerConfig{MinCalls: 1})
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			assert.True(t, errors.Is(fn(ctx), context.DeadlineExceeded))
+			cancel()
+		}
+	})
+	t.Run("Return: ErrMaxAmountOfError - open circuit is failure with any comparator", func(t *testing.T) {
+		fn := verifiers.Breaker(fail, verifiers.BreakerConfig{MinCalls: 1})
+		_ = fn(context.Background())
+		v := verifiers.New(context.Background(), verifiers.WithErrorComparator(func(err error) bool {
+			return false
+		}))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(fn, succeed))
+		report, _ := v.Run(verifiers.OneOf(), fn)
+		assert.Equal(t, verifiers.ErrCircuitOpen, report.Results[0].Err)
+	})
+}
@@ -0,0 +1,99 @@
+package verifiers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheOption configure cache of Cached and Plan.Cached
+type CacheOption func(c *cache)
+
+// NegativeTTL is how long failed result cached, by default same as ttl of successful result
+func NegativeTTL(ttl time.Duration) CacheOption {
+	return func(c *cache) {
+		c.negativeTTL = ttl
+	}
+}
+
+type cacheEntry struct {
+	report *Report
+	err    error
+	at     time.Time
+}
+
+// cache memoize last result of verification, stale result served while other caller refresh it
+type cache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu    sync.Mutex
+	entry *cacheEntry
+	// refreshing closed when refresh finished, nil if nobody refresh result now
+	refreshing chan struct{}
+}
+
+func newCache(ttl time.Duration, options ...CacheOption) *cache {
+	c := &cache{ttl: ttl, negativeTTL: ttl}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+func (c *cache) fresh(entry *cacheEntry) bool {
+	ttl := c.ttl
+	if entry.err != nil {
+		ttl = c.negativeTTL
+	}
+	return time.Since(entry.at) < ttl
+}
+
+// get return cached result or load it. Caller which load result wait it, other callers get stale result
+// or wait loading if nothing cached yet. Result interrupted by context of caller not cached
+func (c *cache) get(ctx context.Context, load func(ctx context.Context) (*Report, error)) (*Report, error) {
+	for {
+		c.mu.Lock()
+		entry := c.entry
+		if entry != nil && (c.refreshing != nil || c.fresh(entry)) {
+			c.mu.Unlock()
+			return entry.report, entry.err
+		}
+		if c.refreshing != nil {
+			refreshing := c.refreshing
+			c.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-refreshing:
+				continue
+			}
+		}
+		refreshing := make(chan struct{})
+		c.refreshing = refreshing
+		c.mu.Unlock()
+
+		report, err := load(ctx)
+		c.mu.Lock()
+		if ctx.Err() == nil {
+			c.entry = &cacheEntry{report: report, err: err, at: time.Now()}
+		}
+		c.refreshing = nil
+		c.mu.Unlock()
+		close(refreshing)
+		return report, err
+	}
+}
+
+// Cached return verifier which memoize result of fn for ttl, name and labels of fn kept.
+// While result refreshed by one caller, other callers get previous result
+func Cached(fn Verifier, ttl time.Duration, options ...CacheOption) Verifier {
+	c := newCache(ttl, options...)
+	name, labels := Describe(fn)
+	return Named(name, func(ctx context.Context) error {
+		_, err := c.get(ctx, func(ctx context.Context) (*Report, error) {
+			return nil, fn(ctx)
+		})
+		return err
+	}, labels...)
+}
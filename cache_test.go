@@ -0,0 +1,129 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCached(t *testing.T) {
+	t.Run("Return: nil - result memoized for ttl", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Cached(verifiers.Named("db", counted(&calls, 0, nil), verifiers.Label{Key: "zone", Value: "a"}), time.Millisecond*100)
+		name, labels := verifiers.Describe(fn)
+		assert.Equal(t, "db", name)
+		assert.Equal(t, []verifiers.Label{{Key: "zone", Value: "a"}}, labels)
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, fn(context.Background()))
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		time.Sleep(time.Millisecond * 150)
+		assert.NoError(t, fn(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: err - failed result cached for negative ttl", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Cached(counted(&calls, 0, someError), time.Hour, verifiers.NegativeTTL(time.Millisecond*50))
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		time.Sleep(time.Millisecond * 80)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: stale result served while refreshed", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		fn := verifiers.Cached(func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return nil
+			}
+			<-release
+			return someError
+		}, time.Millisecond*10)
+		assert.NoError(t, fn(context.Background()))
+		time.Sleep(time.Millisecond * 20)
+
+		refreshed := make(chan error, 1)
+		go func() {
+			refreshed <- fn(context.Background())
+		}()
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&calls) == 2
+		}, time.Second, time.Millisecond)
+		// Refresh in progress, previous result served
+		assert.NoError(t, fn(context.Background()))
+		close(release)
+		assert.Equal(t, someError, <-refreshed)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: concurrent first calls wait one result", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Cached(counted(&calls, time.Millisecond*50, nil), time.Minute)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, fn(context.Background()))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: err - interrupted result not cached", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Cached(func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		}, time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+		assert.True(t, errors.Is(fn(ctx), context.DeadlineExceeded))
+		assert.NoError(t, fn(context.Background()))
+	})
+}
+
+func TestPlan_Cached(t *testing.T) {
+	var calls int32
+	plan := verifiers.MustPlan("zone", verifiers.All(), counted(&calls, 0, nil))
+	cached := plan.Cached(time.Minute)
+
+	first, err := cached.Run(context.Background())
+	require.NoError(t, err)
+	second, err := cached.Run(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	calledOnce := atomic.LoadInt32(&calls)
+	assert.Equal(t, int32(1), calledOnce)
+
+	root := verifiers.MustPlan("root", verifiers.All(), cached.Verifier())
+	_, err = root.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, calledOnce, atomic.LoadInt32(&calls))
+
+	// Original plan not cached
+	_, _ = plan.Run(context.Background())
+	assert.Greater(t, atomic.LoadInt32(&calls), calledOnce)
+
+	slow := verifiers.MustPlan("slow", verifiers.All(), counted(&calls, time.Millisecond*200, nil)).Cached(time.Minute)
+	go func() {
+		_, _ = slow.Run(context.Background())
+	}()
+	time.Sleep(time.Millisecond * 20)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	report, err := slow.Run(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(report.Err, context.DeadlineExceeded))
+}
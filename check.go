@@ -0,0 +1,54 @@
+package verifiers
+
+import "context"
+
+// Check is struct based alternative of Verifier
+type Check interface {
+	Verify(ctx context.Context) error
+	Name() string
+}
+
+// Labeled can be implemented by Check for attach labels to it
+type Labeled interface {
+	Labels() []Label
+}
+
+// Verify call verifier, with Name it makes Verifier implement Check
+func (v Verifier) Verify(ctx context.Context) error {
+	return v(ctx)
+}
+
+// Name return name of verifier, see Describe
+func (v Verifier) Name() string {
+	name, _ := Describe(v)
+	return name
+}
+
+// Labels return labels of verifier, see Describe
+func (v Verifier) Labels() []Label {
+	_, labels := Describe(v)
+	return labels
+}
+
+// FromCheck return named Verifier which call Check.Verify.
+// If check implements Labeled labels will be attached too
+func FromCheck(c Check) Verifier {
+	if v, ok := c.(Verifier); ok {
+		return v
+	}
+	var labels []Label
+	if l, ok := c.(Labeled); ok {
+		labels = l.Labels()
+	}
+	return Named(c.Name(), c.Verify, labels...)
+}
+
+// FromChecks return verifiers for provided checks, so they can be passed to any verifier method.
+// Verifier implements Check, so checks and functions can be mixed
+func FromChecks(checks ...Check) []Verifier {
+	fns := make([]Verifier, len(checks))
+	for index, c := range checks {
+		fns[index] = FromCheck(c)
+	}
+	return fns
+}
@@ -0,0 +1,61 @@
+package verifiers_test
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type thresholdCheck struct {
+	name      string
+	value     int
+	threshold int
+	calls     int
+}
+
+func (c *thresholdCheck) Name() string {
+	return c.name
+}
+
+func (c *thresholdCheck) Labels() []verifiers.Label {
+	return []verifiers.Label{{Key: "kind", Value: "threshold"}}
+}
+
+func (c *thresholdCheck) Verify(ctx context.Context) error {
+	c.calls += 1
+	if c.value < c.threshold {
+		return someError
+	}
+	return nil
+}
+
+func TestFromCheck(t *testing.T) {
+	t.Run("Describe: name and labels of check", func(t *testing.T) {
+		check := &thresholdCheck{name: "disk", value: 10, threshold: 5}
+		name, labels := verifiers.Describe(verifiers.FromCheck(check))
+		assert.Equal(t, "disk", name)
+		assert.Equal(t, []verifiers.Label{{Key: "kind", Value: "threshold"}}, labels)
+		assert.Equal(t, 0, check.calls)
+	})
+	t.Run("Verifier: implements Check", func(t *testing.T) {
+		var check verifiers.Check = verifiers.Named("db", func(ctx context.Context) error {
+			return someError
+		})
+		assert.Equal(t, "db", check.Name())
+		assert.Equal(t, someError, check.Verify(context.Background()))
+		assert.Equal(t, "db", verifiers.FromCheck(check).Name())
+	})
+	t.Run("Return: mixed checks and functions", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		fns := verifiers.FromChecks(
+			&thresholdCheck{name: "disk", value: 10, threshold: 5},
+			&thresholdCheck{name: "memory", value: 1, threshold: 5},
+			verifiers.Verifier(func(ctx context.Context) error {
+				return nil
+			}),
+		)
+		assert.NoError(t, v.Exact(2, fns...))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(fns...))
+	})
+}
@@ -0,0 +1,91 @@
+// Command verifiers run shell commands concurrently and decide result by quorum policy of github.com/PxyUp/verifiers.
+//
+//	verifiers run --at-least 2 --timeout 30s -- 'curl a' ::: 'curl b' ::: 'curl c'
+//	verifiers wait --timeout 1m --interval 2s -- tcp://db:5432 ::: http://api/healthz ::: 'pg_isready'
+//
+// Commands which still running when result decided are killed.
+// Exit codes: 0 - success, 1 - too many errors, 2 - too many successes, 3 - timeout, 64 - usage error, 130 - interrupted
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const (
+	exitSuccess          = 0
+	exitTooManyErrors    = 1
+	exitTooManySuccesses = 2
+	exitTimeout          = 3
+	exitUsage            = 64
+	exitInterrupted      = 130
+)
+
+const usage = `Usage:
+  verifiers run [policy] [--timeout duration] [--format format] -- command [::: command...]
+  verifiers wait [policy] [--timeout duration] [--interval duration] [--attempt-timeout duration] [--format format] -- target [::: target...]
+
+Formats of report printed to stdout: json, junit, tap, markdown
+
+Targets of wait:
+  tcp://host:port  TCP connection can be established
+  unix:///path     UNIX socket connection can be established
+  http(s)://url    request return 2xx status
+  command          shell command exit with 0
+
+Policy (all by default):
+  --all            all commands must succeed
+  --at-least n     at least n commands must succeed
+  --exact n        exactly n commands must succeed
+  --one-of         at least one command must succeed
+  --only-one       exactly one command must succeed
+  --no-one         all commands must fail
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	switch args[0] {
+	case "run":
+		return runCommands(ctx, args[1:], stdout, stderr)
+	case "wait":
+		return waitTargets(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitSuccess
+	}
+	fmt.Fprintf(stderr, "verifiers: unknown command %q\n%s", args[0], usage)
+	return exitUsage
+}
+
+// exitCode map result of verification to exit code of process
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitSuccess
+	case errors.Is(err, verifiers.ErrMaxAmountOfError):
+		return exitTooManyErrors
+	case errors.Is(err, verifiers.ErrMaxAmountOfFinished):
+		return exitTooManySuccesses
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	}
+	return exitUsage
+}
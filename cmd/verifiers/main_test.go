@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func execute(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Return: 0 - all commands succeed", func(t *testing.T) {
+		code, stdout, _ := execute("run", "--", "echo a", ":::", "echo", "b")
+		assert.Equal(t, exitSuccess, code)
+		assert.ElementsMatch(t, []string{"a", "b"}, strings.Fields(stdout))
+	})
+	t.Run("Return: 1 - too many errors", func(t *testing.T) {
+		code, _, stderr := execute("run", "--at-least", "2", "--", "true", ":::", "false", ":::", "exit 3")
+		assert.Equal(t, exitTooManyErrors, code)
+		assert.Contains(t, stderr, "at_least(2)")
+	})
+	t.Run("Return: 2 - too many successes", func(t *testing.T) {
+		code, _, _ := execute("run", "--only-one", "--", "true", ":::", "true")
+		assert.Equal(t, exitTooManySuccesses, code)
+	})
+	t.Run("Return: 3 - timeout", func(t *testing.T) {
+		startTime := time.Now()
+		code, _, _ := execute("run", "--timeout", "100ms", "--", "sleep 10")
+		assert.Equal(t, exitTimeout, code)
+		assert.Less(t, time.Since(startTime), time.Second*5)
+	})
+	t.Run("Return: 130 - interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Millisecond*100, cancel)
+		assert.Equal(t, exitInterrupted, run(ctx, []string{"run", "--", "sleep 10"}, &bytes.Buffer{}, &bytes.Buffer{}))
+	})
+	t.Run("Return: 0 - losers killed with children", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "marker")
+		startTime := time.Now()
+		code, _, _ := execute("run", "--one-of", "--", "true", ":::", "sleep 1 && touch "+marker)
+		assert.Equal(t, exitSuccess, code)
+		assert.Less(t, time.Since(startTime), time.Second)
+		time.Sleep(time.Millisecond * 1500)
+		_, err := os.Stat(marker)
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Return: 0 - report printed in format", func(t *testing.T) {
+		code, stdout, stderr := execute("run", "--format", "json", "--", "echo hello", ":::", "true")
+		assert.Equal(t, exitSuccess, code)
+		assert.Contains(t, stderr, "hello")
+		var report struct {
+			Policy  string `json:"policy"`
+			Passed  bool   `json:"passed"`
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+		assert.Equal(t, "all", report.Policy)
+		assert.True(t, report.Passed)
+		assert.Equal(t, "echo hello", report.Results[0].Name)
+	})
+	t.Run("Return: 64 - usage errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{},
+			{"unknown"},
+			{"run", "--unknown"},
+			{"run", "--all", "--one-of", "--", "true"},
+			{"run", "--", "true", ":::"},
+			{"run"},
+			{"run", "--at-least", "3", "--", "true"},
+			{"run", "--format", "yaml", "--", "true"},
+		} {
+			code, _, _ := execute(args...)
+			assert.Equal(t, exitUsage, code, args)
+		}
+		code, stdout, _ := execute("help")
+		assert.Equal(t, exitSuccess, code)
+		assert.Contains(t, stdout, "--at-least")
+	})
+}
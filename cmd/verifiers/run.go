@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"io"
+	"strings"
+)
+
+// separator of commands in arguments
+const separator = ":::"
+
+var errUsage = errors.New("invalid usage")
+
+// policyFlags register flags of quorum policy, only one of them can be set
+type policyFlags struct {
+	all     bool
+	oneOf   bool
+	onlyOne bool
+	noOne   bool
+	atLeast int
+	exact   int
+}
+
+func (pf *policyFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&pf.all, "all", false, "all must succeed")
+	fs.BoolVar(&pf.oneOf, "one-of", false, "at least one must succeed")
+	fs.BoolVar(&pf.onlyOne, "only-one", false, "exactly one must succeed")
+	fs.BoolVar(&pf.noOne, "no-one", false, "all must fail")
+	fs.IntVar(&pf.atLeast, "at-least", -1, "at least n must succeed")
+	fs.IntVar(&pf.exact, "exact", -1, "exactly n must succeed")
+}
+
+func (pf *policyFlags) policy() (verifiers.Policy, error) {
+	policies := make([]verifiers.Policy, 0, 1)
+	if pf.all {
+		policies = append(policies, verifiers.All())
+	}
+	if pf.oneOf {
+		policies = append(policies, verifiers.OneOf())
+	}
+	if pf.onlyOne {
+		policies = append(policies, verifiers.OnlyOne())
+	}
+	if pf.noOne {
+		policies = append(policies, verifiers.NoOne())
+	}
+	if pf.atLeast >= 0 {
+		policies = append(policies, verifiers.AtLeast(pf.atLeast))
+	}
+	if pf.exact >= 0 {
+		policies = append(policies, verifiers.Exact(pf.exact))
+	}
+	switch len(policies) {
+	case 0:
+		return verifiers.All(), nil
+	case 1:
+		return policies[0], nil
+	}
+	return verifiers.Policy{}, fmt.Errorf("%w: only one policy can be set", errUsage)
+}
+
+// formatFlag is format of report printed to stdout, empty if report should not be printed
+type formatFlag struct {
+	format verifiers.Format
+}
+
+func (ff *formatFlag) String() string {
+	return string(ff.format)
+}
+
+func (ff *formatFlag) Set(value string) error {
+	format, err := verifiers.ParseFormat(value)
+	if err != nil {
+		return err
+	}
+	ff.format = format
+	return nil
+}
+
+// write report to stdout in format of flag
+func (ff *formatFlag) write(stdout io.Writer, stderr io.Writer, report *verifiers.Report) {
+	if ff.format == "" {
+		return
+	}
+	if err := report.Encode(stdout, ff.format); err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s\n", err)
+	}
+}
+
+// splitCommands split arguments by separator, arguments between separators joined by space
+func splitCommands(args []string) ([]string, error) {
+	var commands []string
+	var current []string
+	for _, arg := range append(args, separator) {
+		if arg != separator {
+			current = append(current, arg)
+			continue
+		}
+		if len(current) == 0 {
+			return nil, fmt.Errorf("%w: empty command", errUsage)
+		}
+		commands = append(commands, strings.Join(current, " "))
+		current = nil
+	}
+	return commands, nil
+}
+
+func runCommands(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var pf policyFlags
+	pf.register(fs)
+	timeout := fs.Duration("timeout", 0, "timeout of verification, 0 means no timeout")
+	var ff formatFlag
+	fs.Var(&ff, "format", "print report in format: json, junit, tap or markdown")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	p, err := pf.policy()
+	if err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s\n", err)
+		return exitUsage
+	}
+	commands, err := splitCommands(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s\n", err)
+		return exitUsage
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	out, errOut := &lockedWriter{w: stdout}, &lockedWriter{w: stderr}
+	// Report printed to stdout, so output of commands redirected to stderr
+	if ff.format != "" {
+		out = errOut
+	}
+	// Losers killed after decision, wait them before exit to not leave orphan processes
+	running := &processes{}
+	fns := make([]verifiers.Verifier, len(commands))
+	for index, command := range commands {
+		fns[index] = shell(command, out, errOut, running)
+	}
+	report, err := verifiers.New(ctx).Run(p, fns...)
+	running.wait()
+	if err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s: %s\n", report.Policy, err)
+	}
+	ff.write(stdout, stderr, report)
+	return exitCode(err)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// lockedWriter serialize writes of concurrently running commands
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// processes track running commands, commands can not be started after wait called
+type processes struct {
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func (p *processes) start() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.wg.Add(1)
+	return true
+}
+
+func (p *processes) done() {
+	p.wg.Done()
+}
+
+// wait until all started commands finished
+func (p *processes) wait() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// shell return verifier which run command by "sh -c", command and all its children killed when context done
+func shell(command string, stdout io.Writer, stderr io.Writer, running *processes) verifiers.Verifier {
+	return verifiers.Named(command, func(ctx context.Context) error {
+		// Verification already decided
+		if !running.start() {
+			return ctx.Err()
+		}
+		defer running.done()
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		setProcessGroup(cmd)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			killProcessGroup(cmd)
+			<-done
+			return ctx.Err()
+		}
+	})
+}
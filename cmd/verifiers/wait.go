@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/probes"
+	"io"
+	"strings"
+	"time"
+)
+
+// target return verifier for target of wait subcommand:
+// tcp://host:port, unix:///path, http(s)://url or shell command otherwise
+func target(t string, stdout io.Writer, stderr io.Writer, running *processes) verifiers.Verifier {
+	switch {
+	case strings.HasPrefix(t, "tcp://"):
+		return probes.TCP(strings.TrimPrefix(t, "tcp://"))
+	case strings.HasPrefix(t, "unix://"):
+		return probes.Unix(strings.TrimPrefix(t, "unix://"))
+	case strings.HasPrefix(t, "http://"), strings.HasPrefix(t, "https://"):
+		return probes.HTTP(t)
+	}
+	return shell(t, stdout, stderr, running)
+}
+
+func waitTargets(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("wait", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var pf policyFlags
+	pf.register(fs)
+	timeout := fs.Duration("timeout", 0, "deadline of waiting, 0 means wait forever")
+	interval := fs.Duration("interval", time.Second, "interval between rounds")
+	attemptTimeout := fs.Duration("attempt-timeout", 0, "timeout of single target check, 0 means no timeout")
+	var ff formatFlag
+	fs.Var(&ff, "format", "print report of last round in format: json, junit, tap or markdown")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	p, err := pf.policy()
+	if err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s\n", err)
+		return exitUsage
+	}
+	targets, err := splitCommands(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "verifiers: %s\n", err)
+		return exitUsage
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	running := &processes{}
+	defer running.wait()
+	fns := make([]verifiers.Verifier, len(targets))
+	for index, t := range targets {
+		fns[index] = target(t, io.Discard, io.Discard, running)
+		if *attemptTimeout > 0 {
+			fns[index] = verifiers.Timeout(fns[index], *attemptTimeout)
+		}
+	}
+
+	report, err := verifiers.New(ctx).Eventually(*interval, p, fns...)
+	if err != nil {
+		return failWait(stdout, stderr, ff, report, err)
+	}
+	ff.write(stdout, stderr, report)
+	return exitSuccess
+}
+
+// failWait print error and failed targets of last finished round
+func failWait(stdout io.Writer, stderr io.Writer, ff formatFlag, last *verifiers.Report, err error) int {
+	fmt.Fprintf(stderr, "verifiers: %s: %s\n", last.Policy, err)
+	for _, result := range last.Results {
+		if result.Outcome == verifiers.OutcomeFailure {
+			fmt.Fprintf(stderr, "  %s: %s\n", result.Name, result.Err)
+		}
+	}
+	ff.write(stdout, stderr, last)
+	return exitCode(err)
+}
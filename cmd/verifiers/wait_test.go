@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	t.Run("Return: 0 - all targets become ready", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+		listeners := make(chan net.Listener, 1)
+		time.AfterFunc(time.Millisecond*200, func() {
+			listener, err := net.Listen("tcp", address)
+			if err == nil {
+				listeners <- listener
+			}
+			close(listeners)
+		})
+		defer func() {
+			for listener := range listeners {
+				_ = listener.Close()
+			}
+		}()
+
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		marker := filepath.Join(t.TempDir(), "marker")
+		time.AfterFunc(time.Millisecond*300, func() {
+			_ = os.WriteFile(marker, nil, 0o600)
+		})
+
+		code, _, stderr := execute("wait", "--interval", "50ms", "--timeout", "5s", "--",
+			"tcp://"+address, ":::", server.URL, ":::", "test -f "+marker)
+		assert.Equal(t, exitSuccess, code, stderr)
+		assert.GreaterOrEqual(t, atomic.LoadInt32(&requests), int32(3))
+	})
+	t.Run("Return: 0 - at least one target ready", func(t *testing.T) {
+		code, _, _ := execute("wait", "--at-least", "1", "--interval", "50ms", "--timeout", "5s", "--", "false", ":::", "true")
+		assert.Equal(t, exitSuccess, code)
+	})
+	t.Run("Return: 3 - deadline passed", func(t *testing.T) {
+		startTime := time.Now()
+		code, stdout, stderr := execute("wait", "--interval", "50ms", "--timeout", "300ms", "--format", "tap", "--", "true", ":::", "exit 1")
+		assert.Equal(t, exitTimeout, code)
+		assert.Contains(t, stderr, "exit 1: exit status 1")
+		assert.Contains(t, stdout, "not ok 2 - exit 1\n")
+		assert.Less(t, time.Since(startTime), time.Second*2)
+	})
+	t.Run("Return: 3 - hanging target limited by attempt timeout", func(t *testing.T) {
+		code, _, stderr := execute("wait", "--interval", "50ms", "--attempt-timeout", "50ms", "--timeout", "300ms", "--", "sleep 10")
+		assert.Equal(t, exitTimeout, code)
+		assert.Contains(t, stderr, "sleep 10: context deadline exceeded")
+	})
+	t.Run("Return: 64 - usage errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"wait", "--interval", "0s", "--", "true"},
+			{"wait", "--at-least", "2", "--", "true"},
+			{"wait"},
+		} {
+			code, _, _ := execute(args...)
+			assert.Equal(t, exitUsage, code, args)
+		}
+	})
+}
@@ -0,0 +1,460 @@
+// Package config load verification plans with checks and policy tree from YAML or JSON.
+// Package has no built-in check types, every type of check registered by Factory in Types
+//
+//	name: health
+//	checks:
+//	  - name: db
+//	    type: tcp
+//	    params: {address: "db:5432"}
+//	    timeout: 2s
+//	    retry: {attempts: 3, delay: 100ms}
+//	    tags: {kind: db}
+//	  - {name: api-a, type: tcp, params: {address: "api-a:80"}}
+//	  - {name: api-b, type: tcp, params: {address: "api-b:80"}}
+//	  - {name: api-c, type: tcp, params: {address: "api-c:80"}}
+//	policy:
+//	  all:
+//	    - db
+//	    - at_least: {count: 2, of: [api-a, api-b, api-c]}
+//
+// Plan above loaded with factory of "tcp" type, for example backed by probes.TCP
+//
+//	plan, err := config.LoadFile("health.yaml", config.Types{
+//		"tcp": func(params map[string]interface{}) (verifiers.Verifier, error) {
+//			address, ok := params["address"].(string)
+//			if !ok {
+//				return nil, fmt.Errorf("parameter address should be string")
+//			}
+//			return probes.TCP(address), nil
+//		},
+//	})
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format of configuration
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// ErrUnknownFormat will be returned if format of configuration not supported
+var ErrUnknownFormat = errors.New("unknown configuration format")
+
+// Factory create verifier of check type from parameters of check
+type Factory func(params map[string]interface{}) (verifiers.Verifier, error)
+
+// Types contains factories of check by type
+type Types map[string]Factory
+
+// ValidationError point to path of invalid value in configuration, like "checks[1].timeout"
+type ValidationError struct {
+	Path string
+	Msg  string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Msg
+}
+
+// ValidationErrors contains all problems of configuration
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for index, err := range e {
+		messages[index] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// LoadFile load plan from file, format detected by extension(.json, .yaml or .yml)
+func LoadFile(path string, types Types) (*verifiers.Plan, error) {
+	var format Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = JSON
+	case ".yaml", ".yml":
+		format = YAML
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data, format, types)
+}
+
+// Load load plan from configuration in provided format, checks created by factories from types.
+// Returned error is ValidationErrors if configuration is invalid
+func Load(data []byte, format Format, types Types) (*verifiers.Plan, error) {
+	var document interface{}
+	switch format {
+	case JSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			return nil, err
+		}
+	case YAML:
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	l := &loader{types: types, registry: verifiers.NewRegistry(), names: map[string]string{}}
+	plan := l.load(normalize(document))
+	if plan == nil || len(l.errs) > 0 {
+		return nil, l.errs
+	}
+	return plan, nil
+}
+
+type loader struct {
+	types    Types
+	registry *verifiers.Registry
+	// names of checks with path of first declaration
+	names map[string]string
+	errs  ValidationErrors
+}
+
+func (l *loader) errorf(path string, format string, args ...interface{}) {
+	l.errs = append(l.errs, &ValidationError{Path: path, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (l *loader) load(document interface{}) *verifiers.Plan {
+	root, ok := l.object("$", document, "name", "checks", "policy")
+	if !ok {
+		return nil
+	}
+	name := "config"
+	if value, ok := root["name"]; ok {
+		name, _ = l.string("name", value)
+	}
+
+	checks, ok := root["checks"].([]interface{})
+	if !ok {
+		l.errorf("checks", "expected list of checks")
+	}
+	for index, check := range checks {
+		l.check(fmt.Sprintf("checks[%d]", index), check)
+	}
+
+	policy, ok := root["policy"]
+	if !ok {
+		l.errorf("policy", "required")
+		return nil
+	}
+	if len(l.errs) > 0 {
+		// Policy refer to checks, so it can not be validated without them
+		return nil
+	}
+	return l.plan("policy", name, policy)
+}
+
+func (l *loader) check(path string, value interface{}) {
+	check, ok := l.object(path, value, "name", "type", "params", "timeout", "retry", "tags")
+	if !ok {
+		return
+	}
+	name, ok := l.string(path+".name", check["name"])
+	if !ok {
+		return
+	}
+	if declared, ok := l.names[name]; ok {
+		l.errorf(path+".name", "check %q already declared in %s", name, declared)
+		return
+	}
+	l.names[name] = path
+	kind, ok := l.string(path+".type", check["type"])
+	if !ok {
+		return
+	}
+	factory, ok := l.types[kind]
+	if !ok {
+		l.errorf(path+".type", "unknown check type %q", kind)
+		return
+	}
+	params := map[string]interface{}{}
+	if value, ok := check["params"]; ok {
+		if params, ok = l.object(path+".params", value); !ok {
+			return
+		}
+	}
+	fn, err := factory(params)
+	if err != nil {
+		l.errorf(path+".params", "%s", err)
+		return
+	}
+	if value, ok := check["timeout"]; ok {
+		timeout, ok := l.duration(path+".timeout", value)
+		if !ok {
+			return
+		}
+		fn = verifiers.Timeout(fn, timeout)
+	}
+	if value, ok := check["retry"]; ok {
+		retry, ok := l.object(path+".retry", value, "attempts", "delay")
+		if !ok {
+			return
+		}
+		attempts, ok := l.count(path+".retry.attempts", retry["attempts"])
+		if !ok {
+			return
+		}
+		if attempts < 1 {
+			l.errorf(path+".retry.attempts", "should be at least 1")
+			return
+		}
+		var delay time.Duration
+		if value, ok := retry["delay"]; ok {
+			if delay, ok = l.duration(path+".retry.delay", value); !ok {
+				return
+			}
+		}
+		fn = verifiers.Retry(fn, attempts, delay)
+	}
+	var tags []verifiers.Label
+	if value, ok := check["tags"]; ok {
+		values, ok := l.object(path+".tags", value)
+		if !ok {
+			return
+		}
+		for _, key := range sortedKeys(values) {
+			tag, ok := l.string(path+".tags."+key, values[key])
+			if !ok {
+				return
+			}
+			tags = append(tags, verifiers.Label{Key: key, Value: tag})
+		}
+	}
+	if err := l.registry.Register(name, fn, tags...); err != nil {
+		l.errorf(path+".name", "%s", err)
+	}
+}
+
+// policies of policy tree, counted policies use {count: n, of: [...]} form
+var policies = map[string]func(count int) verifiers.Policy{
+	"all":      func(int) verifiers.Policy { return verifiers.All() },
+	"any":      func(int) verifiers.Policy { return verifiers.OneOf() },
+	"one_of":   func(int) verifiers.Policy { return verifiers.OneOf() },
+	"only_one": func(int) verifiers.Policy { return verifiers.OnlyOne() },
+	"no_one":   func(int) verifiers.Policy { return verifiers.NoOne() },
+	"at_least": verifiers.AtLeast,
+	"exact":    verifiers.Exact,
+}
+
+const policyNames = "all, any, one_of, only_one, no_one, at_least, exact"
+
+// plan return plan of policy tree node, description of node used as name if name is empty
+func (l *loader) plan(path string, name string, value interface{}) *verifiers.Plan {
+	if expr, ok := value.(string); ok {
+		fn := l.expr(path, expr)
+		if fn == nil {
+			return nil
+		}
+		if name == "" {
+			name = expr
+		}
+		return l.newPlan(path, name, verifiers.All(), fn)
+	}
+	node, ok := l.object(path, value)
+	if !ok {
+		return nil
+	}
+	if len(node) != 1 {
+		l.errorf(path, "expected exactly one policy of %s", policyNames)
+		return nil
+	}
+	kind := sortedKeys(node)[0]
+	newPolicy, ok := policies[kind]
+	if !ok {
+		l.errorf(path+"."+kind, "unknown policy, expected one of %s", policyNames)
+		return nil
+	}
+	path, value = path+"."+kind, node[kind]
+	count, description := 0, kind+"("
+	if kind == "at_least" || kind == "exact" {
+		counted, ok := l.object(path, value, "count", "of")
+		if !ok {
+			return nil
+		}
+		if count, ok = l.count(path+".count", counted["count"]); !ok {
+			return nil
+		}
+		path, value = path+".of", counted["of"]
+		description += strconv.Itoa(count) + ", "
+	}
+	items, ok := value.([]interface{})
+	if !ok || len(items) == 0 {
+		l.errorf(path, "expected non empty list of checks or policies")
+		return nil
+	}
+	fns := make([]verifiers.Verifier, len(items))
+	names := make([]string, len(items))
+	valid := true
+	for index, item := range items {
+		fns[index], names[index] = l.verifier(fmt.Sprintf("%s[%d]", path, index), item)
+		valid = valid && fns[index] != nil
+	}
+	if !valid {
+		return nil
+	}
+	if name == "" {
+		name = description + strings.Join(names, ", ") + ")"
+	}
+	return l.newPlan(path, name, newPolicy(count), fns...)
+}
+
+// verifier return verifier of policy tree node with name of it
+func (l *loader) verifier(path string, value interface{}) (verifiers.Verifier, string) {
+	if expr, ok := value.(string); ok {
+		return l.expr(path, expr), expr
+	}
+	plan := l.plan(path, "", value)
+	if plan == nil {
+		return nil, ""
+	}
+	return plan.Verifier(), plan.Name()
+}
+
+// expr return check by name or compiled expression, see verifiers.ParseExpr
+func (l *loader) expr(path string, expr string) verifiers.Verifier {
+	if fn, ok := l.registry.Get(expr); ok {
+		return fn
+	}
+	plan, err := verifiers.ParseExpr(expr, l.registry)
+	if err != nil {
+		l.errorf(path, "%s", err)
+		return nil
+	}
+	return plan.Verifier()
+}
+
+func (l *loader) newPlan(path string, name string, policy verifiers.Policy, fns ...verifiers.Verifier) *verifiers.Plan {
+	plan, err := verifiers.NewPlan(name, policy, fns...)
+	if err != nil {
+		l.errorf(path, "%s", err)
+		return nil
+	}
+	return plan
+}
+
+// object return map of value and check it contains only allowed keys(any keys if allowed is empty)
+func (l *loader) object(path string, value interface{}, allowed ...string) (map[string]interface{}, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		l.errorf(path, "expected object")
+		return nil, false
+	}
+	if len(allowed) == 0 {
+		return object, true
+	}
+	valid := true
+	for _, key := range sortedKeys(object) {
+		known := false
+		for _, name := range allowed {
+			known = known || key == name
+		}
+		if !known {
+			l.errorf(strings.TrimPrefix(path+"."+key, "$."), "unknown field")
+			valid = false
+		}
+	}
+	return object, valid
+}
+
+func (l *loader) string(path string, value interface{}) (string, bool) {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		l.errorf(path, "expected non empty string")
+		return "", false
+	}
+	return s, true
+}
+
+func (l *loader) count(path string, value interface{}) (int, bool) {
+	count, ok := value.(int)
+	if !ok {
+		l.errorf(path, "expected integer")
+		return 0, false
+	}
+	if count < 0 {
+		l.errorf(path, "%s", verifiers.ErrNegativeCount)
+		return 0, false
+	}
+	return count, true
+}
+
+func (l *loader) duration(path string, value interface{}) (time.Duration, bool) {
+	s, ok := value.(string)
+	if !ok {
+		l.errorf(path, "expected duration like \"1s\"")
+		return 0, false
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil || duration <= 0 {
+		l.errorf(path, "expected positive duration like \"1s\", got %q", s)
+		return 0, false
+	}
+	return duration, true
+}
+
+// normalize convert decoded JSON and YAML into same types: map[string]interface{}, []interface{}, int, float64, string and bool
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = normalize(item)
+		}
+		return object
+	case []interface{}:
+		for index, item := range v {
+			v[index] = normalize(item)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case int64:
+		return int(v)
+	case uint64:
+		return int(v)
+	}
+	return value
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var errFailed = errors.New("failed")
+
+// types contains "static" check which result defined by "ok" parameter
+var types = config.Types{
+	"static": func(params map[string]interface{}) (verifiers.Verifier, error) {
+		ok, valid := params["ok"].(bool)
+		if !valid {
+			return nil, fmt.Errorf("parameter ok should be boolean")
+		}
+		return func(ctx context.Context) error {
+			if !ok {
+				return errFailed
+			}
+			return nil
+		}, nil
+	},
+}
+
+const yamlPlan = `
+name: health
+checks:
+  - name: db
+    type: static
+    params: {ok: true}
+    timeout: 2s
+    retry: {attempts: 2, delay: 1ms}
+    tags: {kind: db}
+  - {name: api-a, type: static, params: {ok: true}}
+  - {name: api-b, type: static, params: {ok: false}}
+  - {name: api-c, type: static, params: {ok: true}}
+  - {name: maintenance, type: static, params: {ok: false}}
+policy:
+  all:
+    - db
+    - at_least: {count: 2, of: [api-a, api-b, api-c]}
+    - "!any(maintenance)"
+`
+
+const jsonPlan = `{
+  "checks": [
+    {"name": "db", "type": "static", "params": {"ok": true}},
+    {"name": "cache", "type": "static", "params": {"ok": false}}
+  ],
+  "policy": {"exact": {"count": 1, "of": ["db", {"all": ["cache"]}]}}
+}`
+
+func TestLoad(t *testing.T) {
+	t.Run("Load: YAML plan", func(t *testing.T) {
+		plan, err := config.Load([]byte(yamlPlan), config.YAML, types)
+		require.NoError(t, err)
+		assert.Equal(t, "health", plan.Name())
+		report, err := plan.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "db", report.Results[0].Name)
+		value, _ := report.Results[0].Label("kind")
+		assert.Equal(t, "db", value)
+		assert.Equal(t, "at_least(2, api-a, api-b, api-c)", report.Results[1].Name)
+		assert.Equal(t, "!any(maintenance)", report.Results[2].Name)
+	})
+	t.Run("Load: JSON plan", func(t *testing.T) {
+		plan, err := config.Load([]byte(jsonPlan), config.JSON, types)
+		require.NoError(t, err)
+		assert.Equal(t, "config", plan.Name())
+		assert.Equal(t, "exact(1)", plan.Policy().String())
+		report, err := plan.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "all(cache)", report.Results[1].Name)
+	})
+	t.Run("Load: expression policy", func(t *testing.T) {
+		plan, err := config.Load([]byte(`{"checks": [{"name": "db", "type": "static", "params": {"ok": true}}], "policy": "db && !db"}`), config.JSON, types)
+		require.NoError(t, err)
+		_, err = plan.Run(context.Background())
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+	})
+	t.Run("Load: file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "plan.yml")
+		require.NoError(t, os.WriteFile(path, []byte(yamlPlan), 0o600))
+		_, err := config.LoadFile(path, types)
+		assert.NoError(t, err)
+		_, err = config.LoadFile(filepath.Join(dir, "plan.toml"), types)
+		assert.True(t, errors.Is(err, config.ErrUnknownFormat))
+	})
+}
+
+func TestLoad_Validation(t *testing.T) {
+	cases := map[string][]string{
+		`checks: [{name: db, type: unknown}]
+policy: db`: {"checks[0].type"},
+		`checks: [{name: db, type: static, params: {ok: 1}, timeout: soon, extra: 1}]
+policy: db`: {"checks[0].extra"},
+		`checks: [{name: db, type: static, params: {ok: true}, timeout: soon, retry: {attempts: 0}}]
+policy: db`: {"checks[0].timeout"},
+		`checks: [{name: db, type: static, params: {ok: true}, retry: {attempts: 0}}]
+policy: db`: {"checks[0].retry.attempts"},
+		`checks: [{name: db, type: static, params: {ok: 1}}, {name: db, type: static, params: {ok: true}}, {type: static}]
+policy: db`: {"checks[0].params", "checks[1].name", "checks[2].name"},
+		`checks: [{name: db, type: static, params: {ok: true}}]
+policy: {all: [db, {at_least: {count: 2, of: [db]}}, {exact: {count: -1, of: [db]}}, {maybe: [db]}, unknown, {}]}`: {
+			"policy.all[1].at_least.of", "policy.all[2].exact.count", "policy.all[3].maybe", "policy.all[4]", "policy.all[5]",
+		},
+		`checks: [{name: db, type: static, params: {ok: true}}]
+policy: {no_one: []}`: {"policy.no_one"},
+		`checks: [{name: db, type: static, params: {ok: true}}]`: {"policy"},
+		`checks: {}
+policy: db`: {"checks"},
+		`[]`: {"$"},
+	}
+	for document, paths := range cases {
+		_, err := config.Load([]byte(document), config.YAML, types)
+		validationErrs := config.ValidationErrors{}
+		require.True(t, errors.As(err, &validationErrs), document)
+		var actual []string
+		for _, validationErr := range validationErrs {
+			actual = append(actual, validationErr.Path)
+		}
+		assert.Equal(t, paths, actual, "%s\n%s", document, err)
+	}
+	_, err := config.Load([]byte(`{`), config.JSON, types)
+	assert.Error(t, err)
+	_, err = config.Load([]byte(`{}`), config.Format("toml"), types)
+	assert.True(t, errors.Is(err, config.ErrUnknownFormat))
+}
@@ -0,0 +1,289 @@
+package verifiers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format of encoded Report
+type Format string
+
+const (
+	// FormatJSON encode report as JSON object, see Report.MarshalJSON
+	FormatJSON Format = "json"
+	// FormatJUnit encode report as JUnit XML test suite
+	FormatJUnit Format = "junit"
+	// FormatTAP encode report as Test Anything Protocol version 13
+	FormatTAP Format = "tap"
+	// FormatMarkdown encode report as Markdown table
+	FormatMarkdown Format = "markdown"
+)
+
+// ErrUnknownFormat will be returned if report can not be encoded in provided format
+var ErrUnknownFormat = errors.New("unknown report format")
+
+// ParseFormat return format by name, "md" and "xml" accepted as aliases
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "json":
+		return FormatJSON, nil
+	case "junit", "xml":
+		return FormatJUnit, nil
+	case "tap":
+		return FormatTAP, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+}
+
+// Encode write report to w in provided format
+func (r *Report) Encode(w io.Writer, format Format) error {
+	switch format {
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatJUnit:
+		return r.WriteJUnit(w)
+	case FormatTAP:
+		return r.WriteTAP(w)
+	case FormatMarkdown:
+		return r.WriteMarkdown(w)
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+type jsonResult struct {
+	Name     string            `json:"name"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Outcome  Outcome           `json:"outcome"`
+	Duration float64           `json:"duration_seconds"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type jsonReport struct {
+	Policy    string       `json:"policy"`
+	Required  int          `json:"required"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Passed    bool         `json:"passed"`
+	Outcome   string       `json:"outcome"`
+	Duration  float64      `json:"duration_seconds"`
+	Error     string       `json:"error,omitempty"`
+	Results   []jsonResult `json:"results"`
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (r *Report) toJSON() jsonReport {
+	report := jsonReport{
+		Policy:    r.Policy.String(),
+		Required:  r.Required,
+		Succeeded: r.Succeeded,
+		Failed:    r.Failed,
+		Passed:    r.Passed(),
+		Outcome:   r.Outcome(),
+		Duration:  r.Duration.Seconds(),
+		Error:     errorString(r.Err),
+		Results:   make([]jsonResult, len(r.Results)),
+	}
+	for index, result := range r.Results {
+		report.Results[index] = jsonResult{
+			Name:     result.Name,
+			Outcome:  result.Outcome,
+			Duration: result.Duration.Seconds(),
+			Error:    errorString(result.Err),
+		}
+		if len(result.Labels) > 0 {
+			report.Results[index].Labels = make(map[string]string, len(result.Labels))
+			for _, label := range result.Labels {
+				report.Results[index].Labels[label.Key] = label.Value
+			}
+		}
+	}
+	return report
+}
+
+// MarshalJSON encode policy decision and results, errors encoded as strings and durations in seconds
+func (r *Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.toJSON())
+}
+
+// MarshalJSON encode report same as Report.MarshalJSON with reports of groups under "groups" key
+func (r *GroupReport) MarshalJSON() ([]byte, error) {
+	groups := make(map[string]jsonReport, len(r.Groups))
+	for name, group := range r.Groups {
+		groups[name] = group.toJSON()
+	}
+	return json.Marshal(struct {
+		jsonReport
+		Groups map[string]jsonReport `json:"groups"`
+	}{jsonReport: r.Report.toJSON(), Groups: groups})
+}
+
+// WriteJSON write indented JSON of report
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitSuite struct {
+	XMLName    xml.Name        `xml:"testsuite"`
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteJUnit write report as JUnit XML test suite, each function is test case with "verifiers" class
+// and decision of policy is last test case with "verifiers.policy" class
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitSuite{
+		Name: "verifiers." + r.Policy.String(),
+		Time: seconds(r.Duration),
+		Properties: []junitProperty{
+			{Name: "policy", Value: r.Policy.String()},
+			{Name: "required", Value: fmt.Sprint(r.Required)},
+			{Name: "succeeded", Value: fmt.Sprint(r.Succeeded)},
+			{Name: "failed", Value: fmt.Sprint(r.Failed)},
+			{Name: "outcome", Value: r.Outcome()},
+		},
+	}
+	for _, result := range r.Results {
+		testCase := junitCase{Name: qualifiedName(result.Name, result.Labels), ClassName: "verifiers", Time: seconds(result.Duration)}
+		switch result.Outcome {
+		case OutcomeFailure:
+			testCase.Failure = &junitMessage{Message: errorString(result.Err), Body: errorString(result.Err)}
+			suite.Failures += 1
+		case OutcomeSuccess:
+		default:
+			testCase.Skipped = &junitMessage{Message: string(result.Outcome)}
+			suite.Skipped += 1
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	decision := junitCase{Name: r.Policy.String(), ClassName: "verifiers.policy", Time: seconds(r.Duration)}
+	if r.Err != nil {
+		decision.Failure = &junitMessage{Message: r.Outcome(), Body: r.Err.Error()}
+		suite.Failures += 1
+	}
+	suite.Cases = append(suite.Cases, decision)
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteTAP write report in Test Anything Protocol version 13, not finished functions reported with SKIP directive
+// and decision of policy is last test point
+func (r *Report) WriteTAP(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "TAP version 13\n1..%d\n", len(r.Results)+1)
+	for index, result := range r.Results {
+		switch result.Outcome {
+		case OutcomeSuccess:
+			fmt.Fprintf(&b, "ok %d - %s\n", index+1, tapEscape(qualifiedName(result.Name, result.Labels)))
+		case OutcomeFailure:
+			fmt.Fprintf(&b, "not ok %d - %s\n", index+1, tapEscape(qualifiedName(result.Name, result.Labels)))
+		default:
+			fmt.Fprintf(&b, "ok %d - %s # SKIP %s\n", index+1, tapEscape(qualifiedName(result.Name, result.Labels)), result.Outcome)
+		}
+		b.WriteString("  ---\n")
+		fmt.Fprintf(&b, "  duration_seconds: %s\n", seconds(result.Duration))
+		if result.Err != nil {
+			fmt.Fprintf(&b, "  error: %q\n", result.Err.Error())
+		}
+		b.WriteString("  ...\n")
+	}
+	status := "ok"
+	if r.Err != nil {
+		status = "not ok"
+	}
+	fmt.Fprintf(&b, "%s %d - policy %s\n", status, len(r.Results)+1, r.Policy)
+	b.WriteString("  ---\n")
+	fmt.Fprintf(&b, "  outcome: %s\n  required: %d\n  succeeded: %d\n  failed: %d\n  duration_seconds: %s\n",
+		r.Outcome(), r.Required, r.Succeeded, r.Failed, seconds(r.Duration))
+	if r.Err != nil {
+		fmt.Fprintf(&b, "  error: %q\n", r.Err.Error())
+	}
+	b.WriteString("  ...\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tapEscape escape characters which have special meaning in description of test point
+func tapEscape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "#", "\\#", "\n", " ").Replace(s)
+}
+
+// WriteMarkdown write decision of policy and table of results
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Policy:** `%s` **Outcome:** %s (required %d, succeeded %d, failed %d, %s)\n\n",
+		r.Policy, r.Outcome(), r.Required, r.Succeeded, r.Failed, r.Duration.Round(time.Millisecond))
+	b.WriteString("| Name | Labels | Outcome | Duration | Error |\n")
+	b.WriteString("|------|--------|---------|----------|-------|\n")
+	for _, result := range r.Results {
+		labels := make([]string, len(result.Labels))
+		for index, label := range result.Labels {
+			labels[index] = label.String()
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			markdownEscape(result.Name),
+			markdownEscape(strings.Join(labels, ", ")),
+			result.Outcome,
+			result.Duration.Round(time.Millisecond),
+			markdownEscape(errorString(result.Err)),
+		)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape escape characters which break table cell
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", "<br>").Replace(s)
+}
@@ -0,0 +1,158 @@
+package verifiers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func sampleReport() *verifiers.Report {
+	return &verifiers.Report{
+		Policy:    verifiers.AtLeast(2),
+		Required:  2,
+		Succeeded: 1,
+		Failed:    2,
+		Duration:  time.Millisecond * 1500,
+		Err:       verifiers.ErrMaxAmountOfError,
+		Results: []verifiers.Result{
+			{Name: "db", Labels: []verifiers.Label{{Key: "zone", Value: "a"}}, Outcome: verifiers.OutcomeSuccess, Duration: time.Millisecond * 250},
+			{Name: "cache|redis", Outcome: verifiers.OutcomeFailure, Err: errors.New("connection refused"), Duration: time.Second},
+			{Name: "api #1", Outcome: verifiers.OutcomeFailure, Err: errors.New("timeout"), Duration: time.Millisecond * 1500},
+			{Name: "cdn", Outcome: verifiers.OutcomeCanceled},
+		},
+	}
+}
+
+func TestReport_Encode(t *testing.T) {
+	t.Run("Return: nil - json", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, sampleReport().Encode(&b, verifiers.FormatJSON))
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+		assert.Equal(t, "at_least(2)", decoded["policy"])
+		assert.Equal(t, false, decoded["passed"])
+		assert.Equal(t, "max_amount_of_error", decoded["outcome"])
+		assert.Equal(t, 1.5, decoded["duration_seconds"])
+		assert.Equal(t, verifiers.ErrMaxAmountOfError.Error(), decoded["error"])
+		results := decoded["results"].([]interface{})
+		require.Len(t, results, 4)
+		assert.Equal(t, map[string]interface{}{
+			"name":             "db",
+			"labels":           map[string]interface{}{"zone": "a"},
+			"outcome":          "success",
+			"duration_seconds": 0.25,
+		}, results[0])
+		assert.Equal(t, "connection refused", results[1].(map[string]interface{})["error"])
+	})
+	t.Run("Return: nil - junit", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, sampleReport().Encode(&b, verifiers.FormatJUnit))
+		var suite struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Skipped  int    `xml:"skipped,attr"`
+			Cases    []struct {
+				Name      string `xml:"name,attr"`
+				ClassName string `xml:"classname,attr"`
+				Time      string `xml:"time,attr"`
+				Failure   *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+				Skipped *struct{} `xml:"skipped"`
+			} `xml:"testcase"`
+		}
+		require.NoError(t, xml.Unmarshal(b.Bytes(), &suite))
+		assert.Equal(t, "verifiers.at_least(2)", suite.Name)
+		assert.Equal(t, 5, suite.Tests)
+		assert.Equal(t, 3, suite.Failures)
+		assert.Equal(t, 1, suite.Skipped)
+		assert.Equal(t, "db{zone=a}", suite.Cases[0].Name)
+		assert.Equal(t, "0.250", suite.Cases[0].Time)
+		assert.Nil(t, suite.Cases[0].Failure)
+		assert.Equal(t, "connection refused", suite.Cases[1].Failure.Message)
+		assert.NotNil(t, suite.Cases[3].Skipped)
+		assert.Equal(t, "verifiers.policy", suite.Cases[4].ClassName)
+		assert.Equal(t, "max_amount_of_error", suite.Cases[4].Failure.Message)
+	})
+	t.Run("Return: nil - tap", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, sampleReport().Encode(&b, verifiers.FormatTAP))
+		assert.Equal(t, `TAP version 13
+1..5
+ok 1 - db{zone=a}
+  ---
+  duration_seconds: 0.250
+  ...
+not ok 2 - cache|redis
+  ---
+  duration_seconds: 1.000
+  error: "connection refused"
+  ...
+not ok 3 - api \#1
+  ---
+  duration_seconds: 1.500
+  error: "timeout"
+  ...
+ok 4 - cdn # SKIP canceled
+  ---
+  duration_seconds: 0.000
+  ...
+not ok 5 - policy at_least(2)
+  ---
+  outcome: max_amount_of_error
+  required: 2
+  succeeded: 1
+  failed: 2
+  duration_seconds: 1.500
+  error: "verifier reach max amount of error"
+  ...
+`, b.String())
+	})
+	t.Run("Return: nil - markdown", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, sampleReport().Encode(&b, verifiers.FormatMarkdown))
+		assert.Equal(t, "**Policy:** `at_least(2)` **Outcome:** max_amount_of_error (required 2, succeeded 1, failed 2, 1.5s)\n\n"+
+			"| Name | Labels | Outcome | Duration | Error |\n"+
+			"|------|--------|---------|----------|-------|\n"+
+			"| db | zone=a | success | 250ms |  |\n"+
+			"| cache\\|redis |  | failure | 1s | connection refused |\n"+
+			"| api #1 |  | failure | 1.5s | timeout |\n"+
+			"| cdn |  | canceled | 0s |  |\n", b.String())
+	})
+	t.Run("Return: err - unknown format", func(t *testing.T) {
+		assert.True(t, errors.Is(sampleReport().Encode(&bytes.Buffer{}, "yaml"), verifiers.ErrUnknownFormat))
+		_, err := verifiers.ParseFormat("yaml")
+		assert.True(t, errors.Is(err, verifiers.ErrUnknownFormat))
+		format, err := verifiers.ParseFormat("MD")
+		assert.NoError(t, err)
+		assert.Equal(t, verifiers.FormatMarkdown, format)
+	})
+	t.Run("Return: nil - json of group report", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		report, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"a": {succeed},
+			"b": {fail, fail},
+		}, verifiers.NoOne(), verifiers.Exact(1))
+		require.NoError(t, err)
+		var decoded struct {
+			Policy string `json:"policy"`
+			Groups map[string]struct {
+				Outcome string `json:"outcome"`
+			} `json:"groups"`
+		}
+		b, err := json.Marshal(report)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, "exact(1)", decoded.Policy)
+		assert.Equal(t, "max_amount_of_finished", decoded.Groups["a"].Outcome)
+		assert.Equal(t, "success", decoded.Groups["b"].Outcome)
+	})
+}
@@ -0,0 +1,40 @@
+package verifiers
+
+import (
+	"fmt"
+	"time"
+)
+
+// Eventually repeat verification with interval between rounds until functions match policy or context of verifier done.
+// Report of passed round returned on success, on failure returned report of last finished round with error of context
+func (f *verifier) Eventually(interval time.Duration, p Policy, fns ...Verifier) (*Report, error) {
+	if err := p.validate(len(fns)); err != nil {
+		return &Report{Policy: p, Required: p.Required(len(fns)), Err: err}, err
+	}
+	if interval <= 0 {
+		err := fmt.Errorf("%w: interval must be positive", ErrInvalidPolicy)
+		return &Report{Policy: p, Required: p.Required(len(fns)), Err: err}, err
+	}
+	var last *Report
+	for {
+		report := f.run(p, fns...)
+		if report.Err == nil {
+			return report, nil
+		}
+		// Round interrupted by context, previous finished round explains failure better
+		if f.ctx.Err() != nil {
+			if last == nil {
+				last = report
+			}
+			return last, f.ctx.Err()
+		}
+		last = report
+		timer := time.NewTimer(interval)
+		select {
+		case <-f.ctx.Done():
+			timer.Stop()
+			return last, f.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
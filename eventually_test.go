@@ -0,0 +1,57 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifier_Eventually(t *testing.T) {
+	t.Run("Return: nil - policy matched after few rounds", func(t *testing.T) {
+		var calls int32
+		flaky := verifiers.Named("flaky", func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return someError
+			}
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		report, err := verifiers.New(ctx).Eventually(time.Millisecond*10, verifiers.All(), flaky, succeed)
+		assert.NoError(t, err)
+		assert.True(t, report.Passed())
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: err - context expired, last finished round returned", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+		defer cancel()
+		startTime := time.Now()
+		report, err := verifiers.New(ctx).Eventually(time.Millisecond*20, verifiers.AtLeast(2), succeed, verifiers.Named("broken", fail))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, time.Since(startTime), time.Second)
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, report.Err)
+		assert.Equal(t, verifiers.OutcomeFailure, report.Results[1].Outcome)
+		assert.Equal(t, "broken", report.Results[1].Name)
+	})
+	t.Run("Return: err - context expired during first round", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		report, err := verifiers.New(ctx).Eventually(time.Millisecond*20, verifiers.All(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.True(t, errors.Is(report.Err, context.DeadlineExceeded))
+	})
+	t.Run("Return: err - invalid policy or interval", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		_, err := v.Eventually(time.Millisecond, verifiers.AtLeast(3), succeed)
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, err)
+		_, err = v.Eventually(0, verifiers.All(), succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrInvalidPolicy))
+	})
+}
@@ -0,0 +1,131 @@
+package verifiers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrRejected will be returned if executor can not accept function, it always counted as failure even with custom error comparator
+var ErrRejected = errors.New("executor rejected function")
+
+// Executor call functions of verifications, see WithExecutor
+type Executor interface {
+	// Submit run task asynchronously in partition, error returned if task can not be accepted
+	Submit(partition string, task func()) error
+}
+
+// goroutines is default executor which run every task in own goroutine
+type goroutines struct{}
+
+func (goroutines) Submit(partition string, task func()) error {
+	go task()
+	return nil
+}
+
+// Partition is capacity of bulkhead partition
+type Partition struct {
+	// Workers is maximal amount of concurrently running tasks, not limited if zero
+	Workers int
+	// Queue is maximal amount of tasks waiting for worker, further tasks rejected with ErrRejected
+	Queue int
+}
+
+type partition struct {
+	capacity Partition
+
+	mu      sync.Mutex
+	running int
+	queue   []func()
+}
+
+func (p *partition) submit(name string, task func()) error {
+	p.mu.Lock()
+	if p.capacity.Workers <= 0 || p.running < p.capacity.Workers {
+		p.running += 1
+		p.mu.Unlock()
+		go p.work(task)
+		return nil
+	}
+	if len(p.queue) >= p.capacity.Queue {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: queue of partition %s is full", ErrRejected, name)
+	}
+	p.queue = append(p.queue, task)
+	p.mu.Unlock()
+	return nil
+}
+
+// work run task and then queued tasks until queue is empty
+func (p *partition) work(task func()) {
+	for task != nil {
+		task()
+		p.mu.Lock()
+		if len(p.queue) > 0 {
+			task, p.queue = p.queue[0], p.queue[1:]
+		} else {
+			task = nil
+			p.running -= 1
+		}
+		p.mu.Unlock()
+	}
+}
+
+// Bulkhead is executor which isolate partitions from each other, every partition has own workers and queue.
+// When workers of partition busy tasks wait in queue, tasks rejected when queue is full
+type Bulkhead struct {
+	defaults Partition
+
+	mu         sync.Mutex
+	partitions map[string]*partition
+}
+
+// NewBulkhead return bulkhead with capacity of named partitions, every other partition has own capacity equal defaults
+func NewBulkhead(defaults Partition, partitions map[string]Partition) *Bulkhead {
+	b := &Bulkhead{defaults: defaults, partitions: make(map[string]*partition, len(partitions))}
+	for name, capacity := range partitions {
+		b.partitions[name] = &partition{capacity: capacity}
+	}
+	return b
+}
+
+func (b *Bulkhead) partition(name string) *partition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p, ok := b.partitions[name]
+	if !ok {
+		p = &partition{capacity: b.defaults}
+		b.partitions[name] = p
+	}
+	return p
+}
+
+// Submit run task in partition or put it in queue of partition
+func (b *Bulkhead) Submit(name string, task func()) error {
+	return b.partition(name).submit(name, task)
+}
+
+// Stats return amount of running and queued tasks of partition
+func (b *Bulkhead) Stats(name string) (running int, queued int) {
+	p := b.partition(name)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.running, len(p.queue)
+}
+
+// WithExecutor will call functions of verifications by executor instead of own goroutines.
+// Functions of nested verifications(groups, nested plans) called inside task of parent function
+func WithExecutor(executor Executor) option {
+	return func(v *verifier) {
+		v.executor = executor
+	}
+}
+
+// WithPartition is partition of executor for functions of verifier, plans use own name if partition not set
+func WithPartition(name string) option {
+	return func(v *verifier) {
+		v.partition = name
+	}
+}
+
+type executorKey struct{}
@@ -0,0 +1,137 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rejectFirst executor reject first submitted task and run others in own goroutines
+type rejectFirst struct {
+	submitted int32
+}
+
+func (e *rejectFirst) Submit(partition string, task func()) error {
+	if atomic.AddInt32(&e.submitted, 1) == 1 {
+		return verifiers.ErrRejected
+	}
+	go task()
+	return nil
+}
+
+func TestWithExecutor(t *testing.T) {
+	t.Run("Return: nil - hanging plan not starve other plans", func(t *testing.T) {
+		bulkhead := verifiers.NewBulkhead(verifiers.Partition{Workers: 2, Queue: 10}, nil)
+		hang := func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		hanging := verifiers.MustPlan("hanging", verifiers.All(), hang, hang, hang)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			_, err := hanging.Run(ctx, verifiers.WithExecutor(bulkhead))
+			done <- err
+		}()
+		require.Eventually(t, func() bool {
+			running, queued := bulkhead.Stats("hanging")
+			return running == 2 && queued == 1
+		}, time.Second, time.Millisecond)
+
+		healthy := verifiers.MustPlan("healthy", verifiers.All(), succeed, succeed, succeed)
+		_, err := healthy.Run(context.Background(), verifiers.WithExecutor(bulkhead))
+		assert.NoError(t, err)
+
+		cancel()
+		assert.True(t, errors.Is(<-done, context.Canceled))
+		require.Eventually(t, func() bool {
+			running, queued := bulkhead.Stats("hanging")
+			return running == 0 && queued == 0
+		}, time.Second, time.Millisecond)
+	})
+	t.Run("Return: ErrMaxAmountOfError - functions rejected when queue is full", func(t *testing.T) {
+		bulkhead := verifiers.NewBulkhead(verifiers.Partition{}, map[string]verifiers.Partition{
+			"small": {Workers: 1, Queue: 1},
+		})
+		slow := func(ctx context.Context) error {
+			time.Sleep(time.Millisecond * 20)
+			return nil
+		}
+		v := verifiers.New(context.Background(), verifiers.WithExecutor(bulkhead), verifiers.WithPartition("small"),
+			verifiers.WithErrorComparator(func(err error) bool {
+				return false
+			}))
+		report, err := v.Run(verifiers.All(), slow, slow, verifiers.Named("rejected", slow))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		assert.True(t, errors.Is(report.Results[2].Err, verifiers.ErrRejected))
+		assert.Equal(t, verifiers.OutcomeFailure, report.Results[2].Outcome)
+
+		// Not configured partition is not limited
+		v = verifiers.New(context.Background(), verifiers.WithExecutor(bulkhead), verifiers.WithPartition("other"))
+		assert.NoError(t, v.All(slow, slow, slow))
+	})
+	t.Run("Return: err - queued functions not called after decision", func(t *testing.T) {
+		var calls int32
+		hang := func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		bulkhead := verifiers.NewBulkhead(verifiers.Partition{Workers: 1, Queue: 10}, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		v := verifiers.New(ctx, verifiers.WithExecutor(bulkhead))
+		assert.Error(t, v.OneOf(hang, hang, hang))
+		require.Eventually(t, func() bool {
+			running, queued := bulkhead.Stats("")
+			return running == 0 && queued == 0
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: nil - rejected groups not awaited", func(t *testing.T) {
+		slow := func(ctx context.Context) error {
+			time.Sleep(time.Millisecond * 20)
+			return nil
+		}
+		bulkhead := verifiers.NewBulkhead(verifiers.Partition{Workers: 1, Queue: 0}, nil)
+		v := verifiers.New(context.Background(), verifiers.WithExecutor(bulkhead))
+		startTime := time.Now()
+		report, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {slow},
+			"zone-b": {slow},
+		}, verifiers.All(), verifiers.OneOf())
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(startTime), time.Second)
+		assert.True(t, report.Groups["zone-a"].Passed())
+		assert.Nil(t, report.Groups["zone-b"])
+	})
+	t.Run("Return: err - dependants of rejected skipped", func(t *testing.T) {
+		graph := verifiers.NewGraph().
+			Add("db", succeed).
+			Add("replication", succeed, "db")
+		v := verifiers.New(context.Background(), verifiers.WithExecutor(&rejectFirst{}))
+		report, err := v.RunGraph(graph, verifiers.OneOf())
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		assert.True(t, errors.Is(report.Results[0].Err, verifiers.ErrRejected))
+		assert.Equal(t, verifiers.OutcomeSkipped, report.Results[1].Outcome)
+	})
+	t.Run("Return: nil - nested verifications run inside task of parent", func(t *testing.T) {
+		bulkhead := verifiers.NewBulkhead(verifiers.Partition{Workers: 1}, nil)
+		v := verifiers.New(context.Background(), verifiers.WithExecutor(bulkhead))
+		report, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed, succeed},
+		}, verifiers.All(), verifiers.All())
+		require.NoError(t, err)
+		assert.True(t, report.Groups["zone-a"].Passed())
+
+		inner := verifiers.MustPlan("inner", verifiers.All(), succeed, succeed)
+		outer := verifiers.MustPlan("outer", verifiers.All(), inner.Verifier())
+		_, err = outer.Run(context.Background(), verifiers.WithExecutor(bulkhead))
+		assert.NoError(t, err)
+	})
+}
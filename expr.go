@@ -0,0 +1,346 @@
+package verifiers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseError is error of ParseExpr with position(1-based offset in expression) of problem
+type ParseError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error at position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	// pos is 0-based offset of token
+	pos int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenIdent, tokenNumber:
+		return strconv.Quote(t.value)
+	}
+	return "'" + t.value + "'"
+}
+
+// policies of expression functions by name
+var exprPolicies = map[string]func(count int) Policy{
+	"all":      func(int) Policy { return All() },
+	"any":      func(int) Policy { return OneOf() },
+	"oneof":    func(int) Policy { return OneOf() },
+	"one_of":   func(int) Policy { return OneOf() },
+	"atleast":  AtLeast,
+	"at_least": AtLeast,
+	"exact":    Exact,
+	"onlyone":  func(int) Policy { return OnlyOne() },
+	"only_one": func(int) Policy { return OnlyOne() },
+	"none":     func(int) Policy { return NoOne() },
+	"noone":    func(int) Policy { return NoOne() },
+	"no_one":   func(int) Policy { return NoOne() },
+}
+
+// functions of expression which require count as first argument
+var exprCounted = map[string]bool{"atleast": true, "at_least": true, "exact": true}
+
+type exprParser struct {
+	expr     string
+	tokens   []token
+	current  int
+	registry *Registry
+}
+
+// ParseExpr compile expression into Plan, names in expression resolved against registry.
+// Supported functions: all, any(one_of), at_least(n, ...), exact(n, ...), only_one, no_one(none).
+// Operators: "a && b" is all(a, b), "a || b" is any(a, b), "!a" is no_one(a), parentheses group expressions.
+//
+//	all(db, cache) && atleast(2, api-a, api-b, api-c) && !any(maintenance)
+func ParseExpr(expr string, registry *Registry) (*Plan, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{expr: expr, tokens: tokens, registry: registry}
+	start := p.peek()
+	fn, plan, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	if plan != nil {
+		return plan, nil
+	}
+	// Single name without operators
+	return p.plan(start, strings.TrimSpace(expr), All(), fn)
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(expr); {
+		c := rune(expr[pos])
+		switch {
+		case unicode.IsSpace(c):
+			pos += 1
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: pos})
+			pos += 1
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: pos})
+			pos += 1
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: pos})
+			pos += 1
+		case c == '!':
+			tokens = append(tokens, token{kind: tokenNot, value: "!", pos: pos})
+			pos += 1
+		case strings.HasPrefix(expr[pos:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, value: "&&", pos: pos})
+			pos += 2
+		case strings.HasPrefix(expr[pos:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, value: "||", pos: pos})
+			pos += 2
+		case isNameChar(c):
+			start := pos
+			for pos < len(expr) && isNameChar(rune(expr[pos])) {
+				pos += 1
+			}
+			kind := tokenIdent
+			if strings.Trim(expr[start:pos], "0123456789") == "" {
+				kind = tokenNumber
+			}
+			tokens = append(tokens, token{kind: kind, value: expr[start:pos], pos: start})
+		default:
+			return nil, &ParseError{Expr: expr, Pos: pos + 1, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+func isNameChar(c rune) bool {
+	return c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("-_.:/", c))
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.current]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.current]
+	if t.kind != tokenEOF {
+		p.current += 1
+	}
+	return t
+}
+
+func (p *exprParser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.errorf(t, "expected %s, found %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *exprParser) errorf(t token, format string, args ...interface{}) error {
+	return &ParseError{Expr: p.expr, Pos: t.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// source return trimmed expression from start token to current token
+func (p *exprParser) source(start token) string {
+	end := len(p.expr)
+	if p.current > 0 {
+		last := p.tokens[p.current-1]
+		end = last.pos + len(last.value)
+	}
+	return p.expr[start.pos:end]
+}
+
+// plan create nested plan, validation errors reported with position of start token
+func (p *exprParser) plan(start token, name string, policy Policy, fns ...Verifier) (*Plan, error) {
+	plan, err := NewPlan(name, policy, fns...)
+	if err != nil {
+		return nil, p.errorf(start, "%s", err)
+	}
+	return plan, nil
+}
+
+// parseOr, parseAnd, parseUnary and parsePrimary return verifier of parsed expression
+// and plan if expression is not single name
+
+func (p *exprParser) parseOr() (Verifier, *Plan, error) {
+	return p.parseBinary(tokenOr, OneOf(), p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() (Verifier, *Plan, error) {
+	return p.parseBinary(tokenAnd, All(), p.parseUnary)
+}
+
+func (p *exprParser) parseBinary(kind tokenKind, policy Policy, operand func() (Verifier, *Plan, error)) (Verifier, *Plan, error) {
+	start := p.peek()
+	fn, plan, err := operand()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.peek().kind != kind {
+		return fn, plan, nil
+	}
+	fns := []Verifier{fn}
+	for p.peek().kind == kind {
+		p.next()
+		fn, _, err = operand()
+		if err != nil {
+			return nil, nil, err
+		}
+		fns = append(fns, fn)
+	}
+	plan, err = p.plan(start, p.source(start), policy, fns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan.Verifier(), plan, nil
+}
+
+func (p *exprParser) parseUnary() (Verifier, *Plan, error) {
+	start := p.peek()
+	if start.kind != tokenNot {
+		return p.parsePrimary()
+	}
+	p.next()
+	var fns []Verifier
+	if p.anyCall() {
+		// !any(a, b) is no_one(a, b)
+		p.current += 2
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, nil, err
+		}
+		fns = args
+	} else {
+		fn, _, err := p.parseUnary()
+		if err != nil {
+			return nil, nil, err
+		}
+		fns = []Verifier{fn}
+	}
+	plan, err := p.plan(start, p.source(start), NoOne(), fns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan.Verifier(), plan, nil
+}
+
+// anyCall return true if current token is start of any(...) call
+func (p *exprParser) anyCall() bool {
+	t := p.peek()
+	if t.kind != tokenIdent || p.tokens[p.current+1].kind != tokenLParen {
+		return false
+	}
+	policy, ok := exprPolicies[strings.ToLower(t.value)]
+	return ok && policy(0).name == "one_of"
+}
+
+func (p *exprParser) parsePrimary() (Verifier, *Plan, error) {
+	start := p.next()
+	switch start.kind {
+	case tokenLParen:
+		fn, plan, err := p.parseOr()
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, nil, err
+		}
+		return fn, plan, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(start)
+		}
+		if p.registry != nil {
+			if fn, ok := p.registry.Get(start.value); ok {
+				return fn, nil, nil
+			}
+		}
+		return nil, nil, p.errorf(start, "unknown verifier %s", start)
+	}
+	return nil, nil, p.errorf(start, "expected verifier name, function or '(', found %s", start)
+}
+
+func (p *exprParser) parseCall(start token) (Verifier, *Plan, error) {
+	name := strings.ToLower(start.value)
+	policy, ok := exprPolicies[name]
+	if !ok {
+		return nil, nil, p.errorf(start, "unknown function %s", start)
+	}
+	p.next()
+	count := 0
+	if exprCounted[name] {
+		t, err := p.expect(tokenNumber, "count")
+		if err != nil {
+			return nil, nil, err
+		}
+		if count, err = strconv.Atoi(t.value); err != nil {
+			return nil, nil, p.errorf(t, "invalid count %s", t)
+		}
+		if _, err := p.expect(tokenComma, "','"); err != nil {
+			return nil, nil, err
+		}
+	}
+	fns, err := p.parseArgs()
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := p.plan(start, p.source(start), policy(count), fns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan.Verifier(), plan, nil
+}
+
+// parseArgs parse comma separated expressions until closing parenthesis
+func (p *exprParser) parseArgs() ([]Verifier, error) {
+	var fns []Verifier
+	if p.peek().kind == tokenRParen {
+		return nil, p.errorf(p.peek(), "expected at least one argument")
+	}
+	for {
+		fn, _, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		fns = append(fns, fn)
+		t := p.next()
+		switch t.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return fns, nil
+		}
+		return nil, p.errorf(t, "expected ',' or ')', found %s", t)
+	}
+}
@@ -0,0 +1,95 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newExprRegistry(t *testing.T, failed ...string) *verifiers.Registry {
+	registry := verifiers.NewRegistry()
+	isFailed := map[string]bool{}
+	for _, name := range failed {
+		isFailed[name] = true
+	}
+	for _, name := range []string{"db", "cache", "api-a", "api-b", "api-c", "maintenance"} {
+		fn := succeed
+		if isFailed[name] {
+			fn = fail
+		}
+		require.NoError(t, registry.Register(name, fn))
+	}
+	return registry
+}
+
+func TestParseExpr(t *testing.T) {
+	const expr = "all(db, cache) && atleast(2, api-a, api-b, api-c) && !any(maintenance)"
+	t.Run("Return: nil - policy matched", func(t *testing.T) {
+		plan, err := verifiers.ParseExpr(expr, newExprRegistry(t, "api-b", "maintenance"))
+		require.NoError(t, err)
+		assert.Equal(t, expr, plan.Name())
+		assert.Equal(t, "all", plan.Policy().Name())
+		report, err := plan.Run(context.Background())
+		assert.NoError(t, err)
+		require.Len(t, report.Results, 3)
+		assert.Equal(t, "all(db, cache)", report.Results[0].Name)
+		assert.Equal(t, "atleast(2, api-a, api-b, api-c)", report.Results[1].Name)
+		assert.Equal(t, "!any(maintenance)", report.Results[2].Name)
+	})
+	t.Run("Return: err - policy not matched", func(t *testing.T) {
+		for _, failed := range [][]string{{"cache"}, {"api-a", "api-c"}, {}} {
+			plan, err := verifiers.ParseExpr(expr, newExprRegistry(t, failed...))
+			require.NoError(t, err)
+			_, err = plan.Run(context.Background())
+			assert.Equal(t, verifiers.ErrMaxAmountOfError, err, failed)
+		}
+	})
+	t.Run("Return: nil - operators and nesting", func(t *testing.T) {
+		registry := newExprRegistry(t, "db", "api-c")
+		cases := map[string]error{
+			"cache":                             nil,
+			"db":                                verifiers.ErrMaxAmountOfError,
+			"db || cache":                       nil,
+			"!db && (cache || api-c)":           nil,
+			"!(db || api-c)":                    nil,
+			"!!db":                              verifiers.ErrMaxAmountOfFinished,
+			"exact(2, api-a, api-b, api-c)":     nil,
+			"only_one(db, api-c, all(api-a))":   nil,
+			"none(db, api-c) && one_of(db, db)": verifiers.ErrMaxAmountOfError,
+			"at_least(1, all(db, cache), api-c || api-a)": nil,
+		}
+		for expr, expected := range cases {
+			plan, err := verifiers.ParseExpr(expr, registry)
+			require.NoError(t, err, expr)
+			_, err = plan.Run(context.Background())
+			assert.Equal(t, expected, err, expr)
+		}
+	})
+	t.Run("Return: err - parse errors with position", func(t *testing.T) {
+		registry := newExprRegistry(t)
+		cases := map[string]int{
+			"all(db, cache":             14,
+			"all(db,, cache)":           8,
+			"allof(db)":                 1,
+			"all(db) && unknown":        12,
+			"atleast(db, cache)":        9,
+			"atleast(3, db, cache)":     1,
+			"all(db) cache":             9,
+			"all(db) & cache":           9,
+			"all()":                     5,
+			"":                          1,
+			"all(db) && !any(api-a, x)": 24,
+			"exact(-1, db)":             7,
+			"!":                         2,
+		}
+		for expr, pos := range cases {
+			_, err := verifiers.ParseExpr(expr, registry)
+			parseErr := &verifiers.ParseError{}
+			require.True(t, errors.As(err, &parseErr), expr)
+			assert.Equal(t, pos, parseErr.Pos, "%s: %s", expr, err)
+		}
+	})
+}
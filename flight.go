@@ -0,0 +1,97 @@
+package verifiers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// detachedContext keep values of parent but not its cancellation and deadline
+type detachedContext struct {
+	parent context.Context
+}
+
+func (d detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (d detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (d detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+type flightCall struct {
+	done    chan struct{}
+	report  *Report
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flight share one in-flight verification between concurrent callers
+type flight struct {
+	mu   sync.Mutex
+	call *flightCall
+}
+
+// do join in-flight verification or start new one. Verification canceled only when contexts of all waiting callers done,
+// caller which context done stop waiting with error of context
+func (g *flight) do(ctx context.Context, load func(ctx context.Context) (*Report, error)) (*Report, error) {
+	g.mu.Lock()
+	call := g.call
+	if call == nil {
+		loadCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.call = call
+		go func() {
+			report, err := load(loadCtx)
+			g.mu.Lock()
+			call.report, call.err = report, err
+			if g.call == call {
+				g.call = nil
+			}
+			g.mu.Unlock()
+			cancel()
+			close(call.done)
+		}()
+	}
+	call.waiters += 1
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.report, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters -= 1
+		// Nobody wait result, next caller start new verification
+		if call.waiters == 0 {
+			call.cancel()
+			if g.call == call {
+				g.call = nil
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Singleflight return verifier which share one call of fn between concurrent callers, name and labels of fn kept.
+// Call of fn canceled only when contexts of all waiting callers done
+func Singleflight(fn Verifier) Verifier {
+	g := &flight{}
+	name, labels := Describe(fn)
+	return Named(name, func(ctx context.Context) error {
+		_, err := g.do(ctx, func(ctx context.Context) (*Report, error) {
+			return nil, fn(ctx)
+		})
+		return err
+	}, labels...)
+}
@@ -0,0 +1,111 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type flightKey struct{}
+
+func TestSingleflight(t *testing.T) {
+	t.Run("Return: err - concurrent callers share one call", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Singleflight(verifiers.Named("db", counted(&calls, time.Millisecond*100, someError)))
+		name, _ := verifiers.Describe(fn)
+		assert.Equal(t, "db", name)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, someError, fn(context.Background()))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		// Finished call not reused
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: nil - canceled caller not cancel call for others", func(t *testing.T) {
+		var calls int32
+		fn := verifiers.Singleflight(func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			assert.Equal(t, "value", ctx.Value(flightKey{}))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond * 100):
+				return nil
+			}
+		})
+		short, cancel := context.WithTimeout(context.WithValue(context.Background(), flightKey{}, "value"), time.Millisecond*20)
+		defer cancel()
+		shortErr := make(chan error, 1)
+		go func() {
+			shortErr <- fn(short)
+		}()
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&calls) == 1
+		}, time.Second, time.Millisecond)
+		startTime := time.Now()
+		assert.NoError(t, fn(context.Background()))
+		assert.True(t, errors.Is(<-shortErr, context.DeadlineExceeded))
+		assert.Greater(t, time.Since(startTime), time.Millisecond*50)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: err - call canceled when all callers gone", func(t *testing.T) {
+		canceled := make(chan struct{})
+		fn := verifiers.Singleflight(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(canceled)
+			return ctx.Err()
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+		assert.True(t, errors.Is(fn(ctx), context.DeadlineExceeded))
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			assert.Fail(t, "call not canceled")
+		}
+	})
+}
+
+func TestPlan_Singleflight(t *testing.T) {
+	var calls int32
+	plan := verifiers.MustPlan("zone", verifiers.All(), counted(&calls, time.Millisecond*100, nil)).Singleflight()
+	reports := make(chan *verifiers.Report, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report, err := plan.Run(context.Background())
+			assert.NoError(t, err)
+			reports <- report
+		}()
+	}
+	wg.Wait()
+	close(reports)
+	first := <-reports
+	for report := range reports {
+		assert.Same(t, first, report)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	cached := plan.Cached(time.Minute)
+	_, err := cached.Run(context.Background())
+	assert.NoError(t, err)
+	_, err = cached.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,205 @@
+package verifiers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCycle will be returned by Graph.Build if dependencies of verifiers contain cycle
+	ErrCycle = errors.New("verifiers graph contains cycle")
+	// ErrUnknownDependency will be returned by Graph.Build if verifier depends on not added verifier
+	ErrUnknownDependency = errors.New("verifier depends on unknown verifier")
+	// ErrSkipped is error of verifier which not called because one of dependencies failed
+	ErrSkipped = errors.New("verifier skipped because dependency failed")
+)
+
+// OutcomeSkipped function not called because one of dependencies failed
+const OutcomeSkipped Outcome = "skipped"
+
+type node struct {
+	name      string
+	fn        Verifier
+	dependsOn []string
+}
+
+// Graph contains verifiers with dependencies between them, see verifier.RunGraph
+type Graph struct {
+	nodes []node
+	index map[string]int
+	// order of nodes in topological order, nil if graph not built
+	order []int
+	err   error
+}
+
+// NewGraph return empty Graph
+func NewGraph() *Graph {
+	return &Graph{index: map[string]int{}}
+}
+
+// Add verifier with provided name which will be called only after all dependencies finished without error.
+// Errors of graph returned by Build
+func (g *Graph) Add(name string, fn Verifier, dependsOn ...string) *Graph {
+	g.order = nil
+	if _, ok := g.index[name]; ok {
+		if g.err == nil {
+			g.err = fmt.Errorf("%w: %s", ErrDuplicateName, name)
+		}
+		return g
+	}
+	g.index[name] = len(g.nodes)
+	g.nodes = append(g.nodes, node{name: name, fn: fn, dependsOn: dependsOn})
+	return g
+}
+
+// Build validate graph: names are unique, dependencies exist and graph has no cycle
+func (g *Graph) Build() error {
+	if g.err != nil {
+		return g.err
+	}
+	if g.order != nil {
+		return nil
+	}
+	for _, n := range g.nodes {
+		for _, dependency := range n.dependsOn {
+			if _, ok := g.index[dependency]; !ok {
+				return fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, n.name, dependency)
+			}
+		}
+	}
+	if cycle := g.cycle(); cycle != nil {
+		return fmt.Errorf("%w: %s", ErrCycle, strings.Join(cycle, " -> "))
+	}
+
+	// Kahn's algorithm by levels, nodes of same level keep order of adding
+	pending := make([]int, len(g.nodes))
+	dependants := make([][]int, len(g.nodes))
+	for index, n := range g.nodes {
+		pending[index] = len(n.dependsOn)
+		for _, dependency := range n.dependsOn {
+			dependants[g.index[dependency]] = append(dependants[g.index[dependency]], index)
+		}
+	}
+	order := make([]int, 0, len(g.nodes))
+	done := make([]bool, len(g.nodes))
+	for len(order) < len(g.nodes) {
+		level := len(order)
+		for index := range g.nodes {
+			if !done[index] && pending[index] == 0 {
+				done[index] = true
+				order = append(order, index)
+			}
+		}
+		for _, index := range order[level:] {
+			for _, dependant := range dependants[index] {
+				pending[dependant] -= 1
+			}
+		}
+	}
+	g.order = order
+	return nil
+}
+
+// cycle return names of nodes which create cycle, nil if graph has no cycle
+func (g *Graph) cycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.nodes))
+	var path []string
+	var visit func(index int) []string
+	visit = func(index int) []string {
+		state[index] = visiting
+		path = append(path, g.nodes[index].name)
+		for _, dependency := range g.nodes[index].dependsOn {
+			next := g.index[dependency]
+			switch state[next] {
+			case visiting:
+				for start, name := range path {
+					if name == dependency {
+						return append(append([]string{}, path[start:]...), dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[index] = visited
+		return nil
+	}
+	for index := range g.nodes {
+		if state[index] == unvisited {
+			if cycle := visit(index); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// RunGraph verify verifiers of graph match provided policy.
+// Verifiers called with maximal parallelism as soon as all dependencies finished without error,
+// dependants of failed verifier not called and reported as OutcomeSkipped(counted as failed).
+// Verifier takes slot of executor and limits only after dependencies finished, results of report in topological order
+func (f *verifier) RunGraph(g *Graph, p Policy) (*Report, error) {
+	if err := g.Build(); err != nil {
+		return &Report{Policy: p, Err: err}, err
+	}
+
+	type state struct {
+		done   chan struct{}
+		failed bool
+	}
+	states := make([]*state, len(g.nodes))
+	for index := range g.nodes {
+		states[index] = &state{done: make(chan struct{})}
+	}
+	fns := make([]Verifier, len(g.order))
+	for position, index := range g.order {
+		n, s := g.nodes[index], states[index]
+		_, labels := Describe(n.fn)
+		fns[position] = Named(n.name, func(ctx context.Context) (err error) {
+			defer func() {
+				s.failed = f.failed(err)
+				close(s.done)
+			}()
+			return n.fn(ctx)
+		}, labels...)
+	}
+
+	graphVerifier := f.with(f.ctx)
+	graphVerifier.ready = func(ctx context.Context, position int) error {
+		for _, name := range g.nodes[g.order[position]].dependsOn {
+			dependency := states[g.index[name]]
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-dependency.done:
+				if dependency.failed {
+					return ErrSkipped
+				}
+			}
+		}
+		return nil
+	}
+	// Dependants of function which never called, for example rejected by executor, must not wait for it
+	graphVerifier.notCalled = func(position int) {
+		s := states[g.order[position]]
+		s.failed = true
+		close(s.done)
+	}
+	report, err := graphVerifier.Run(p, fns...)
+	for index := range report.Results {
+		if errors.Is(report.Results[index].Err, ErrSkipped) {
+			report.Results[index].Outcome = OutcomeSkipped
+		}
+	}
+	return report, err
+}
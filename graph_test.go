@@ -0,0 +1,116 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGraph_Build(t *testing.T) {
+	t.Run("Return: err - cycle", func(t *testing.T) {
+		err := verifiers.NewGraph().
+			Add("db", succeed, "replication").
+			Add("cache", succeed).
+			Add("replication", succeed, "cache", "db").
+			Build()
+		assert.True(t, errors.Is(err, verifiers.ErrCycle))
+		assert.Contains(t, err.Error(), "db -> replication -> db")
+	})
+	t.Run("Return: err - unknown dependency", func(t *testing.T) {
+		err := verifiers.NewGraph().Add("replication", succeed, "db").Build()
+		assert.True(t, errors.Is(err, verifiers.ErrUnknownDependency))
+	})
+	t.Run("Return: err - duplicate name", func(t *testing.T) {
+		err := verifiers.NewGraph().Add("db", succeed).Add("db", succeed).Build()
+		assert.True(t, errors.Is(err, verifiers.ErrDuplicateName))
+	})
+}
+
+func TestVerifier_RunGraph(t *testing.T) {
+	t.Run("Return: nil - dependants called after dependencies", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls []string
+		record := func(name string) verifiers.Verifier {
+			return func(ctx context.Context) error {
+				time.Sleep(time.Millisecond * 10)
+				mu.Lock()
+				defer mu.Unlock()
+				calls = append(calls, name)
+				return nil
+			}
+		}
+		graph := verifiers.NewGraph().
+			Add("replication", record("replication"), "db", "network").
+			Add("db", record("db"), "network").
+			Add("network", record("network")).
+			Add("cache", record("cache"))
+		v := verifiers.New(context.Background())
+		report, err := v.RunGraph(graph, verifiers.All())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"network", "cache", "db", "replication"}, []string{
+			report.Results[0].Name, report.Results[1].Name, report.Results[2].Name, report.Results[3].Name,
+		})
+		assert.Len(t, calls, 4)
+		assert.Equal(t, "db", calls[2])
+		assert.Equal(t, "replication", calls[3])
+	})
+	t.Run("Return: err - dependants of failed skipped", func(t *testing.T) {
+		called := false
+		graph := verifiers.NewGraph().
+			Add("db", fail).
+			Add("replication", func(ctx context.Context) error {
+				called = true
+				return nil
+			}, "db").
+			Add("lag", succeed, "replication").
+			Add("cache", succeed)
+		v := verifiers.New(context.Background())
+		report, err := v.RunGraph(graph, verifiers.AtLeast(1))
+		assert.NoError(t, err)
+		report, err = v.RunGraph(graph, verifiers.Exact(1))
+		assert.NoError(t, err)
+		assert.False(t, called)
+		assert.Equal(t, verifiers.OutcomeFailure, report.Results[0].Outcome)
+		assert.Equal(t, verifiers.OutcomeSuccess, report.Results[1].Outcome)
+		assert.Equal(t, verifiers.OutcomeSkipped, report.Results[2].Outcome)
+		assert.Equal(t, verifiers.OutcomeSkipped, report.Results[3].Outcome)
+		assert.Equal(t, 3, report.Failed)
+	})
+	t.Run("Return: nil - dependants not take limits before dependencies finished", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		db := verifiers.Label{Key: "host", Value: "db"}
+		graph := verifiers.NewGraph().
+			Add("a", verifiers.Named("a", succeed, db)).
+			Add("b", verifiers.Named("b", succeed, db), "a").
+			Add("c", verifiers.Named("c", succeed, db), "a")
+		v := verifiers.New(ctx, verifiers.WithConcurrencyLimit("host", 1))
+		startTime := time.Now()
+		_, err := v.RunGraph(graph, verifiers.All())
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(startTime), time.Second)
+	})
+	t.Run("Return: nil - skipped dependants not take rate limit", func(t *testing.T) {
+		graph := verifiers.NewGraph().
+			Add("a", fail).
+			Add("b", succeed, "a").
+			Add("c", succeed, "b").
+			Add("d", succeed)
+		v := verifiers.New(context.Background(), verifiers.WithRateLimit(0.1, 2))
+		startTime := time.Now()
+		report, err := v.RunGraph(graph, verifiers.Exact(1))
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(startTime), time.Second)
+		assert.Equal(t, verifiers.OutcomeSkipped, report.Results[2].Outcome)
+		assert.Equal(t, verifiers.OutcomeSkipped, report.Results[3].Outcome)
+	})
+	t.Run("Return: err - build error", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		_, err := v.RunGraph(verifiers.NewGraph().Add("a", succeed, "a"), verifiers.All())
+		assert.True(t, errors.Is(err, verifiers.ErrCycle))
+	})
+}
@@ -0,0 +1,80 @@
+package verifiers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// GroupReport is report of verifier.PerGroup, embedded Report contains result of each group
+type GroupReport struct {
+	*Report
+	// Groups contains report of each group by name
+	Groups map[string]*Report
+}
+
+// with return copy of verifier with provided context
+func (f *verifier) with(ctx context.Context) *verifier {
+	child := *f
+	child.ctx = ctx
+	child.notCalled = nil
+	child.ready = nil
+	return &child
+}
+
+// PerGroup verify each group match perGroup policy and amount of matched groups match overall policy.
+// Groups verified concurrently, remaining functions of group canceled once group is decided.
+// Every group result labeled with "group" label
+func (f *verifier) PerGroup(groups map[string][]Verifier, perGroup Policy, overall Policy) (*GroupReport, error) {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := &GroupReport{Groups: make(map[string]*Report, len(groups))}
+	if err := overall.validate(len(names)); err != nil {
+		report.Report = &Report{Policy: overall, Required: overall.Required(len(names)), Err: err}
+		return report, err
+	}
+	for _, name := range names {
+		if len(groups[name]) == 0 {
+			err := fmt.Errorf("%w: group %s is empty", ErrNoVerifiers, name)
+			report.Report = &Report{Policy: overall, Err: err}
+			return report, err
+		}
+		if err := perGroup.validate(len(groups[name])); err != nil {
+			report.Report = &Report{Policy: overall, Err: err}
+			return report, err
+		}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	fns := make([]Verifier, len(names))
+	for index, name := range names {
+		name, group := name, groups[name]
+		fns[index] = Named(name, func(ctx context.Context) error {
+			defer wg.Done()
+			groupReport := f.with(ctx).run(perGroup, group...)
+			mu.Lock()
+			report.Groups[name] = groupReport
+			mu.Unlock()
+			return groupReport.Err
+		}, Label{Key: "group", Value: name})
+	}
+
+	// Every group function either called or reported as not called, so waiting never hang
+	wg.Add(len(fns))
+	overallVerifier := f.with(f.ctx)
+	overallVerifier.notCalled = func(int) {
+		wg.Done()
+	}
+	report.Report = overallVerifier.run(overall, fns...)
+	// Not finished groups canceled together with verification, so waiting is short
+	wg.Wait()
+	return report, report.Err
+}
@@ -0,0 +1,72 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifier_PerGroup(t *testing.T) {
+	t.Run("Return: nil - healthy replica in each zone", func(t *testing.T) {
+		var canceled int32
+		slow := func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				atomic.AddInt32(&canceled, 1)
+				return ctx.Err()
+			case <-time.After(time.Second * 3):
+				return nil
+			}
+		}
+		v := verifiers.New(context.Background())
+		startTime := time.Now()
+		report, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed, slow},
+			"zone-b": {fail, succeed, slow},
+			"zone-c": {slow, succeed},
+		}, verifiers.OneOf(), verifiers.All())
+		assert.NoError(t, err)
+		assert.True(t, time.Since(startTime) < time.Second)
+		assert.Equal(t, 3, report.Succeeded)
+		assert.Equal(t, "zone-a", report.Results[0].Name)
+		require.Len(t, report.Groups, 3)
+		assert.True(t, report.Groups["zone-b"].Passed())
+		assert.Equal(t, verifiers.OutcomeCanceled, report.Groups["zone-b"].Results[2].Outcome)
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&canceled))
+	})
+	t.Run("Return: err - one group without healthy replica", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		report, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed},
+			"zone-b": {fail, fail},
+		}, verifiers.OneOf(), verifiers.All())
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		require.Len(t, report.Groups, 2)
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, report.Groups["zone-b"].Err)
+		value, ok := report.Results[1].Label("group")
+		assert.True(t, ok)
+		assert.Equal(t, "zone-b", value)
+	})
+	t.Run("Return: err - invalid groups", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		_, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed},
+			"zone-b": {},
+		}, verifiers.OneOf(), verifiers.All())
+		assert.True(t, errors.Is(err, verifiers.ErrNoVerifiers))
+		_, err = v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed},
+		}, verifiers.AtLeast(2), verifiers.All())
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, err)
+		_, err = v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed},
+		}, verifiers.OneOf(), verifiers.AtLeast(2))
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, err)
+	})
+}
@@ -0,0 +1,130 @@
+package verifiers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HandlerOption configure handler returned by HealthHandler
+type HandlerOption func(h *healthHandler)
+
+// CacheTTL is how long report of plan served without verification, measured from end of verification
+func CacheTTL(ttl time.Duration) HandlerOption {
+	return func(h *healthHandler) {
+		h.ttl = ttl
+	}
+}
+
+// MinInterval is minimal interval between starts of verification, last report served for more frequent requests
+func MinInterval(interval time.Duration) HandlerOption {
+	return func(h *healthHandler) {
+		h.minInterval = interval
+	}
+}
+
+// RunTimeout limit verification of plan, verification not canceled when request canceled because result shared between requests
+func RunTimeout(timeout time.Duration) HandlerOption {
+	return func(h *healthHandler) {
+		h.timeout = timeout
+	}
+}
+
+// VerifierOptions is options of verifier used to verify plan
+func VerifierOptions(options ...option) HandlerOption {
+	return func(h *healthHandler) {
+		h.options = options
+	}
+}
+
+type healthHandler struct {
+	plan        *Plan
+	ttl         time.Duration
+	minInterval time.Duration
+	timeout     time.Duration
+	options     []option
+
+	mu       sync.Mutex
+	report   *Report
+	started  time.Time
+	finished time.Time
+	// running closed when current verification finished, nil if plan not verified now
+	running chan struct{}
+}
+
+// HealthHandler return handler which verify plan on request and respond with JSON report,
+// status is 200 if plan matched and 503 otherwise. Concurrent requests share one verification
+func HealthHandler(plan *Plan, options ...HandlerOption) http.Handler {
+	h := &healthHandler{plan: plan}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report, checkedAt, err := h.verify(r.Context())
+	if err != nil {
+		// Client gone, nobody to respond
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Passed() {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(struct {
+		Name      string    `json:"name"`
+		CheckedAt time.Time `json:"checked_at"`
+		jsonReport
+	}{Name: h.plan.Name(), CheckedAt: checkedAt, jsonReport: report.toJSON()})
+}
+
+// verify return cached report or wait verification of plan, which started if nobody started it
+func (h *healthHandler) verify(ctx context.Context) (*Report, time.Time, error) {
+	h.mu.Lock()
+	now := time.Now()
+	if h.report != nil && (now.Sub(h.finished) < h.ttl || now.Sub(h.started) < h.minInterval) {
+		defer h.mu.Unlock()
+		return h.report, h.finished, nil
+	}
+	if h.running == nil {
+		h.running = make(chan struct{})
+		h.started = now
+		go h.run(h.running)
+	}
+	running := h.running
+	h.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, time.Time{}, ctx.Err()
+	case <-running:
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.report, h.finished, nil
+}
+
+func (h *healthHandler) run(running chan struct{}) {
+	ctx := context.Background()
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+	report, _ := h.plan.Run(ctx, h.options...)
+	h.mu.Lock()
+	h.report, h.finished, h.running = report, time.Now(), nil
+	h.mu.Unlock()
+	close(running)
+}
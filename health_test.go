@@ -0,0 +1,127 @@
+package verifiers_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type healthResponse struct {
+	Name    string `json:"name"`
+	Policy  string `json:"policy"`
+	Passed  bool   `json:"passed"`
+	Results []struct {
+		Name    string `json:"name"`
+		Outcome string `json:"outcome"`
+		Error   string `json:"error"`
+	} `json:"results"`
+}
+
+func getHealth(t *testing.T, url string) (int, healthResponse) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var body healthResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+// counted return verifier which count calls and return result of fn
+func counted(calls *int32, delay time.Duration, err error) verifiers.Verifier {
+	return func(ctx context.Context) error {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(delay)
+		return err
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	t.Run("Return: 200 and 503 by decision of plan", func(t *testing.T) {
+		healthy := verifiers.MustPlan("readyz", verifiers.AtLeast(1), verifiers.Named("db", succeed), verifiers.Named("cache", fail))
+		unhealthy := verifiers.MustPlan("healthz", verifiers.All(), verifiers.Named("db", succeed), verifiers.Named("cache", fail))
+		mux := http.NewServeMux()
+		mux.Handle("/readyz", verifiers.HealthHandler(healthy))
+		mux.Handle("/healthz", verifiers.HealthHandler(unhealthy))
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		code, body := getHealth(t, server.URL+"/readyz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "readyz", body.Name)
+		assert.True(t, body.Passed)
+
+		code, body = getHealth(t, server.URL+"/healthz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "all", body.Policy)
+		assert.False(t, body.Passed)
+		require.Len(t, body.Results, 2)
+		assert.Equal(t, "cache", body.Results[1].Name)
+
+		resp, err := http.Head(server.URL + "/healthz")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		_ = resp.Body.Close()
+	})
+	t.Run("Return: cached report served within ttl", func(t *testing.T) {
+		var calls int32
+		plan := verifiers.MustPlan("healthz", verifiers.All(), counted(&calls, 0, nil))
+		server := httptest.NewServer(verifiers.HealthHandler(plan, verifiers.CacheTTL(time.Millisecond*200)))
+		defer server.Close()
+		for i := 0; i < 5; i++ {
+			code, _ := getHealth(t, server.URL)
+			assert.Equal(t, http.StatusOK, code)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		time.Sleep(time.Millisecond * 250)
+		getHealth(t, server.URL)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: plan not verified more often than min interval", func(t *testing.T) {
+		var calls int32
+		plan := verifiers.MustPlan("healthz", verifiers.All(), counted(&calls, 0, someError))
+		server := httptest.NewServer(verifiers.HealthHandler(plan, verifiers.MinInterval(time.Hour)))
+		defer server.Close()
+		for i := 0; i < 3; i++ {
+			code, _ := getHealth(t, server.URL)
+			assert.Equal(t, http.StatusServiceUnavailable, code)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: concurrent requests share verification", func(t *testing.T) {
+		var calls int32
+		plan := verifiers.MustPlan("healthz", verifiers.All(), counted(&calls, time.Millisecond*100, nil))
+		server := httptest.NewServer(verifiers.HealthHandler(plan))
+		defer server.Close()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				code, _ := getHealth(t, server.URL)
+				assert.Equal(t, http.StatusOK, code)
+			}()
+		}
+		wg.Wait()
+		assert.Less(t, atomic.LoadInt32(&calls), int32(10))
+	})
+	t.Run("Return: 503 - verification limited by timeout", func(t *testing.T) {
+		plan := verifiers.MustPlan("healthz", verifiers.All(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		server := httptest.NewServer(verifiers.HealthHandler(plan, verifiers.RunTimeout(time.Millisecond*50)))
+		defer server.Close()
+		code, body := getHealth(t, server.URL)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.False(t, body.Passed)
+	})
+}
@@ -0,0 +1,138 @@
+package verifiers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket limit rate of function starts, bucket refilled with rate tokens per second up to burst
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// wait take token from bucket, wait for it if bucket is empty
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens -= 1
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// resourceLimit limit amount of concurrently called functions with same value of label
+type resourceLimit struct {
+	key   string
+	limit int
+
+	mu        sync.Mutex
+	semaphore map[string]chan struct{}
+}
+
+func (r *resourceLimit) acquire(ctx context.Context, value string) error {
+	r.mu.Lock()
+	semaphore, ok := r.semaphore[value]
+	if !ok {
+		semaphore = make(chan struct{}, r.limit)
+		r.semaphore[value] = semaphore
+	}
+	r.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case semaphore <- struct{}{}:
+		return nil
+	}
+}
+
+func (r *resourceLimit) release(value string) {
+	r.mu.Lock()
+	semaphore := r.semaphore[value]
+	r.mu.Unlock()
+	<-semaphore
+}
+
+// WithRateLimit will limit starts of functions by perSecond with burst, limit shared by all verifiers created with same option.
+// Functions which wait start canceled together with verification, not positive perSecond disable limit
+func WithRateLimit(perSecond float64, burst int) option {
+	if !(perSecond > 0) {
+		return func(v *verifier) {
+			v.rateLimit = nil
+		}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	bucket := &tokenBucket{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+	return func(v *verifier) {
+		v.rateLimit = bucket
+	}
+}
+
+// WithConcurrencyLimit will limit amount of concurrently called functions with same value of label key,
+// like "host" label of network probes.
+// Functions without such label not limited, limit shared by all verifiers created with same option
+func WithConcurrencyLimit(key string, limit int) option {
+	if limit < 1 {
+		limit = 1
+	}
+	resource := &resourceLimit{key: key, limit: limit, semaphore: map[string]chan struct{}{}}
+	return func(v *verifier) {
+		v.resourceLimits = append(v.resourceLimits, resource)
+	}
+}
+
+// acquire wait token of rate limit and slots of resources of function, returned function release slots
+func (f *verifier) acquire(ctx context.Context, labels []Label) (func(), error) {
+	if f.rateLimit != nil {
+		if err := f.rateLimit.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	// Slots acquired in order of options, so functions with several resources can not deadlock
+	acquired := make([]func(), 0, len(f.resourceLimits))
+	release := func() {
+		for index := len(acquired) - 1; index >= 0; index-- {
+			acquired[index]()
+		}
+	}
+	for _, limit := range f.resourceLimits {
+		value, ok := Result{Labels: labels}.Label(limit.key)
+		if !ok {
+			continue
+		}
+		if err := limit.acquire(ctx, value); err != nil {
+			release()
+			return nil, err
+		}
+		limit, value := limit, value
+		acquired = append(acquired, func() {
+			limit.release(value)
+		})
+	}
+	return release, nil
+}
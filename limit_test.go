@@ -0,0 +1,152 @@
+package verifiers_test
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrency track maximal amount of concurrently called functions
+type concurrency struct {
+	current int32
+	max     int32
+}
+
+func (c *concurrency) verifier(delay time.Duration) verifiers.Verifier {
+	return func(ctx context.Context) error {
+		current := atomic.AddInt32(&c.current, 1)
+		defer atomic.AddInt32(&c.current, -1)
+		for {
+			max := atomic.LoadInt32(&c.max)
+			if current <= max || atomic.CompareAndSwapInt32(&c.max, max, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		return nil
+	}
+}
+
+func host(name string, fn verifiers.Verifier) verifiers.Verifier {
+	return verifiers.Named("check", fn, verifiers.Label{Key: "host", Value: name})
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Run("Return: nil - starts limited by rate", func(t *testing.T) {
+		v := verifiers.New(context.Background(), verifiers.WithRateLimit(20, 1))
+		startTime := time.Now()
+		assert.NoError(t, v.All(succeed, succeed, succeed, succeed, succeed))
+		assert.GreaterOrEqual(t, time.Since(startTime), time.Millisecond*150)
+	})
+	t.Run("Return: nil - burst started immediately", func(t *testing.T) {
+		v := verifiers.New(context.Background(), verifiers.WithRateLimit(1, 3))
+		startTime := time.Now()
+		assert.NoError(t, v.All(succeed, succeed, succeed))
+		assert.Less(t, time.Since(startTime), time.Millisecond*500)
+	})
+	t.Run("Return: nil - waiting canceled when decision made", func(t *testing.T) {
+		var calls int32
+		v := verifiers.New(context.Background(), verifiers.WithRateLimit(0.5, 1))
+		startTime := time.Now()
+		assert.NoError(t, v.OneOf(counted(&calls, 0, nil), counted(&calls, 0, nil), counted(&calls, 0, nil)))
+		assert.Less(t, time.Since(startTime), time.Millisecond*500)
+		time.Sleep(time.Millisecond * 50)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Return: nil - limit shared by plan runs with same option", func(t *testing.T) {
+		limit := verifiers.WithRateLimit(10, 1)
+		plan := verifiers.MustPlan("plan", verifiers.All(), succeed)
+		startTime := time.Now()
+		for i := 0; i < 2; i++ {
+			_, err := plan.Run(context.Background(), limit)
+			assert.NoError(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(startTime), time.Millisecond*80)
+	})
+	t.Run("Return: nil - not positive rate disable limit", func(t *testing.T) {
+		v := verifiers.New(context.Background(), verifiers.WithRateLimit(0, 1))
+		startTime := time.Now()
+		assert.NoError(t, v.All(succeed, succeed, succeed))
+		assert.Less(t, time.Since(startTime), time.Millisecond*100)
+	})
+	t.Run("Return: err - groups waiting for rate canceled by context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		v := verifiers.New(ctx, verifiers.WithRateLimit(0.1, 1))
+		startTime := time.Now()
+		_, err := v.PerGroup(map[string][]verifiers.Verifier{
+			"zone-a": {succeed},
+			"zone-b": {succeed},
+		}, verifiers.All(), verifiers.All())
+		assert.Error(t, err)
+		assert.Less(t, time.Since(startTime), time.Second)
+	})
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	t.Run("Return: nil - concurrency limited per resource", func(t *testing.T) {
+		a, b, free := &concurrency{}, &concurrency{}, &concurrency{}
+		v := verifiers.New(context.Background(), verifiers.WithConcurrencyLimit("host", 1))
+		startTime := time.Now()
+		assert.NoError(t, v.All(
+			host("a", a.verifier(time.Millisecond*50)),
+			host("a", a.verifier(time.Millisecond*50)),
+			host("b", b.verifier(time.Millisecond*50)),
+			host("b", b.verifier(time.Millisecond*50)),
+			free.verifier(time.Millisecond*50),
+			free.verifier(time.Millisecond*50),
+		))
+		assert.Equal(t, int32(1), a.max)
+		assert.Equal(t, int32(1), b.max)
+		assert.Equal(t, int32(2), free.max)
+		assert.Less(t, time.Since(startTime), time.Millisecond*150)
+	})
+	t.Run("Return: nil - limit shared by verifications of verifier", func(t *testing.T) {
+		a := &concurrency{}
+		v := verifiers.New(context.Background(), verifiers.WithConcurrencyLimit("host", 2))
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, v.All(host("a", a.verifier(time.Millisecond*20)), host("a", a.verifier(time.Millisecond*20))))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), a.max)
+	})
+	t.Run("Return: nil - limit shared by plan runs with same option", func(t *testing.T) {
+		a := &concurrency{}
+		limit := verifiers.WithConcurrencyLimit("host", 1)
+		plan := verifiers.MustPlan("plan", verifiers.All(), host("a", a.verifier(time.Millisecond*20)))
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := plan.Run(context.Background(), limit)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&a.max))
+	})
+	t.Run("Return: err - waiting canceled by context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		v := verifiers.New(ctx, verifiers.WithConcurrencyLimit("host", 1))
+		blocked := host("a", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		startTime := time.Now()
+		report, err := v.Run(verifiers.All(), blocked, blocked)
+		assert.Error(t, err)
+		assert.False(t, report.Passed())
+		assert.Less(t, time.Since(startTime), time.Millisecond*500)
+	})
+}
@@ -0,0 +1,195 @@
+package verifiers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// State of plan tracked by Monitor
+type State string
+
+const (
+	// StateUnknown plan not verified yet
+	StateUnknown State = "unknown"
+	// StateHealthy plan matched and all functions finished without error
+	StateHealthy State = "healthy"
+	// StateDegraded plan matched but some functions finished with error
+	StateDegraded State = "degraded"
+	// StateUnhealthy plan not matched
+	StateUnhealthy State = "unhealthy"
+)
+
+// severity used to decide is state going down or up
+func (s State) severity() int {
+	switch s {
+	case StateHealthy:
+		return 1
+	case StateDegraded:
+		return 2
+	case StateUnhealthy:
+		return 3
+	}
+	return 0
+}
+
+// stateOf return state observed in single verification
+func stateOf(report *Report) State {
+	switch {
+	case !report.Passed():
+		return StateUnhealthy
+	case report.Failed > 0:
+		return StateDegraded
+	}
+	return StateHealthy
+}
+
+// Transition of monitor from one state to another
+type Transition struct {
+	From State
+	To   State
+	// Report of verification which caused transition
+	Report *Report
+	At     time.Time
+}
+
+// MonitorOption configure Monitor
+type MonitorOption func(m *Monitor)
+
+// FallAfter is amount of consecutive rounds with worse state required to go down, 1 by default
+func FallAfter(rounds int) MonitorOption {
+	return func(m *Monitor) {
+		m.fall = rounds
+	}
+}
+
+// RiseAfter is amount of consecutive rounds with better state required to go up, 1 by default
+func RiseAfter(rounds int) MonitorOption {
+	return func(m *Monitor) {
+		m.rise = rounds
+	}
+}
+
+// Monitor periodically verify plan and track its state with flap damping
+type Monitor struct {
+	plan     *Plan
+	interval time.Duration
+	fall     int
+	rise     int
+
+	mu     sync.Mutex
+	state  State
+	report *Report
+	// count of last consecutive rounds with state worse(direction is 1) or better(direction is -1) than current
+	direction   int
+	count       int
+	subscribers []subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	id int
+	fn func(Transition)
+}
+
+// NewMonitor return monitor which verify plan every interval, first verified state applied immediately
+func NewMonitor(plan *Plan, interval time.Duration, options ...MonitorOption) *Monitor {
+	m := &Monitor{
+		plan:     plan,
+		interval: interval,
+		fall:     1,
+		rise:     1,
+		state:    StateUnknown,
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+// State return current state
+func (m *Monitor) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Report return report of last verification, nil if plan not verified yet
+func (m *Monitor) Report() *Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.report
+}
+
+// Subscribe add function called on every transition, functions called in order of subscription by goroutine of Monitor.Run.
+// Returned function remove subscription
+func (m *Monitor) Subscribe(fn func(Transition)) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.nextID
+	m.nextID += 1
+	m.subscribers = append(m.subscribers, subscriber{id: id, fn: fn})
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for index, s := range m.subscribers {
+			if s.id == id {
+				m.subscribers = append(m.subscribers[:index:index], m.subscribers[index+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Run verify plan with provided options every interval until context done, return error of context
+func (m *Monitor) Run(ctx context.Context, options ...option) error {
+	for {
+		report, _ := m.plan.Run(ctx, options...)
+		// Verification interrupted by context, its result is not state of plan
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.observe(report)
+		timer := time.NewTimer(m.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observe apply state of report and notify subscribers if state changed.
+// State changed to observed one after FallAfter consecutive worse rounds or RiseAfter consecutive better rounds
+func (m *Monitor) observe(report *Report) {
+	observed := stateOf(report)
+	m.mu.Lock()
+	m.report = report
+	direction, required := 0, 0
+	switch {
+	case observed.severity() > m.state.severity():
+		direction, required = 1, m.fall
+	case observed.severity() < m.state.severity():
+		direction, required = -1, m.rise
+	}
+	if direction != m.direction {
+		m.direction, m.count = direction, 0
+	}
+	m.count += 1
+	if direction == 0 || (m.state != StateUnknown && m.count < required) {
+		m.mu.Unlock()
+		return
+	}
+	transition := Transition{From: m.state, To: observed, Report: report, At: time.Now()}
+	m.state, m.direction, m.count = observed, 0, 0
+	subscribers := make([]func(Transition), len(m.subscribers))
+	for index, s := range m.subscribers {
+		subscribers[index] = s.fn
+	}
+	m.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(transition)
+	}
+}
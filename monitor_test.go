@@ -0,0 +1,101 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// script is like sequence, but success returned after short delay, so failures of same round always finished first
+func script(pattern string) verifiers.Verifier {
+	fn := sequence(pattern)
+	return func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond * 10):
+			return nil
+		}
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	t.Run("Return: transitions with flap damping", func(t *testing.T) {
+		// rounds: healthy, degraded, healthy, degraded, degraded, unhealthy, unhealthy, healthy, healthy
+		plan := verifiers.MustPlan("zone", verifiers.AtLeast(1),
+			script("+++++--++"),
+			script("+-+----++"),
+		)
+		monitor := verifiers.NewMonitor(plan, time.Millisecond, verifiers.FallAfter(2), verifiers.RiseAfter(2))
+		assert.Equal(t, verifiers.StateUnknown, monitor.State())
+		assert.Nil(t, monitor.Report())
+
+		transitions := make(chan verifiers.Transition, 10)
+		monitor.Subscribe(func(transition verifiers.Transition) {
+			transitions <- transition
+		})
+		var once int32
+		var unsubscribe func()
+		unsubscribe = monitor.Subscribe(func(transition verifiers.Transition) {
+			atomic.AddInt32(&once, 1)
+			unsubscribe()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- monitor.Run(ctx)
+		}()
+		var states [][2]verifiers.State
+		for len(states) < 4 {
+			select {
+			case transition := <-transitions:
+				states = append(states, [2]verifiers.State{transition.From, transition.To})
+				assert.NotNil(t, transition.Report)
+			case <-time.After(time.Second * 5):
+				require.FailNow(t, "transition not happened", states)
+			}
+		}
+		cancel()
+		assert.True(t, errors.Is(<-done, context.Canceled))
+		assert.Equal(t, [][2]verifiers.State{
+			{verifiers.StateUnknown, verifiers.StateHealthy},
+			{verifiers.StateHealthy, verifiers.StateDegraded},
+			{verifiers.StateDegraded, verifiers.StateUnhealthy},
+			{verifiers.StateUnhealthy, verifiers.StateHealthy},
+		}, states)
+		assert.Equal(t, verifiers.StateHealthy, monitor.State())
+		assert.True(t, monitor.Report().Passed())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&once))
+		assert.Len(t, transitions, 0)
+	})
+	t.Run("Return: state changed on every round without damping", func(t *testing.T) {
+		plan := verifiers.MustPlan("db", verifiers.All(), script("+-+"))
+		monitor := verifiers.NewMonitor(plan, time.Millisecond)
+		transitions := make(chan verifiers.Transition, 10)
+		monitor.Subscribe(func(transition verifiers.Transition) {
+			transitions <- transition
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = monitor.Run(ctx)
+		}()
+		for _, expected := range []verifiers.State{verifiers.StateHealthy, verifiers.StateUnhealthy, verifiers.StateHealthy} {
+			select {
+			case transition := <-transitions:
+				assert.Equal(t, expected, transition.To)
+			case <-time.After(time.Second * 5):
+				require.FailNow(t, "transition not happened")
+			}
+		}
+	})
+}
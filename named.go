@@ -0,0 +1,90 @@
+package verifiers
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Label is key-value metadata of named verifier
+type Label struct {
+	Key   string
+	Value string
+}
+
+// String return label in key=value form
+func (l Label) String() string {
+	return l.Key + "=" + l.Value
+}
+
+// VerifierError is error returned by named verifier, used in logs and spans of verification
+type VerifierError struct {
+	Name   string
+	Labels []Label
+	Err    error
+}
+
+func (e *VerifierError) Error() string {
+	return qualifiedName(e.Name, e.Labels) + ": " + e.Err.Error()
+}
+
+// qualifiedName return name with labels, like "db{zone=a}"
+func qualifiedName(name string, labels []Label) string {
+	if len(labels) == 0 {
+		return name
+	}
+	pairs := make([]string, len(labels))
+	for index, label := range labels {
+		pairs[index] = label.String()
+	}
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (e *VerifierError) Unwrap() error {
+	return e.Err
+}
+
+type named struct {
+	name   string
+	labels []Label
+	fn     Verifier
+}
+
+type describeKey struct{}
+
+func (n *named) verify(ctx context.Context) error {
+	if out, ok := ctx.Value(describeKey{}).(**named); ok {
+		*out = n
+		return nil
+	}
+	return n.fn(ctx)
+}
+
+// namedPointer is code pointer shared by all verifiers created with Named
+var namedPointer = reflect.ValueOf((&named{}).verify).Pointer()
+
+// Named return verifier with provided name and labels which will be used in logs, spans and errors instead of function name
+func Named(name string, v Verifier, labels ...Label) Verifier {
+	return (&named{name: name, labels: labels, fn: v}).verify
+}
+
+// Describe return name and labels of verifier.
+// For verifier which not created with Named will be returned name of function symbol
+func Describe(fn Verifier) (string, []Label) {
+	if fn == nil {
+		return "", nil
+	}
+	if reflect.ValueOf(fn).Pointer() == namedPointer {
+		var n *named
+		// Named verifier just describe itself with such context, wrapped function not called
+		_ = fn(context.WithValue(context.Background(), describeKey{}, &n))
+		if n != nil {
+			return n.name, n.labels
+		}
+	}
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name(), nil
+	}
+	return "", nil
+}
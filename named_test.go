@@ -0,0 +1,64 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	t.Run("Describe: name and labels", func(t *testing.T) {
+		called := false
+		fn := verifiers.Named("db", func(ctx context.Context) error {
+			called = true
+			return nil
+		}, verifiers.Label{Key: "env", Value: "prod"})
+		name, labels := verifiers.Describe(fn)
+		assert.Equal(t, "db", name)
+		assert.Equal(t, []verifiers.Label{{Key: "env", Value: "prod"}}, labels)
+		assert.False(t, called)
+
+		other, _ := verifiers.Describe(verifiers.Named("cache", fn))
+		assert.Equal(t, "cache", other)
+	})
+	t.Run("Describe: function name for not named", func(t *testing.T) {
+		name, labels := verifiers.Describe(func(ctx context.Context) error {
+			return nil
+		})
+		assert.True(t, strings.HasPrefix(name, "github.com/PxyUp/verifiers_test.TestNamed"))
+		assert.Nil(t, labels)
+	})
+	t.Run("Verify: call wrapped function", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(
+			verifiers.Named("db", func(ctx context.Context) error {
+				return someError
+			}),
+			verifiers.Named("cache", func(ctx context.Context) error {
+				return nil
+			}),
+		))
+	})
+	t.Run("Trace: name and labels of span", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		v := verifiers.New(context.Background(), verifiers.WithTracer(tracer))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(
+			verifiers.Named("db", func(ctx context.Context) error {
+				return someError
+			}, verifiers.Label{Key: "zone", Value: "a"}),
+		))
+		tracer.mu.Lock()
+		defer tracer.mu.Unlock()
+		child := tracer.spans[1]
+		assert.Equal(t, "db", child.attrs["verifiers.name"])
+		assert.Equal(t, "a", child.attrs["verifiers.label.zone"])
+		verifierErr := &verifiers.VerifierError{}
+		assert.True(t, errors.As(child.err, &verifierErr))
+		assert.Equal(t, "db", verifierErr.Name)
+		assert.ErrorIs(t, child.err, someError)
+		assert.Equal(t, "db{zone=a}: some error", child.err.Error())
+	})
+}
@@ -0,0 +1,24 @@
+module github.com/PxyUp/verifiers/otelverifiers
+
+go 1.18
+
+require (
+	github.com/PxyUp/verifiers v0.1.0
+	github.com/stretchr/testify v1.8.2
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/sdk v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Core module developed in same repository. Release order: tag core vX.Y.Z first,
+// bump require of github.com/PxyUp/verifiers to vX.Y.Z, then tag otelverifiers/vX.Y.Z
+replace github.com/PxyUp/verifiers => ../
@@ -0,0 +1,63 @@
+// Package otelverifiers provide OpenTelemetry adapter for verifiers.Tracer
+package otelverifiers
+
+import (
+	"context"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer return verifiers.Tracer which start spans with provided OpenTelemetry tracer
+func NewTracer(t trace.Tracer) verifiers.Tracer {
+	return &tracer{tracer: t}
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attrs ...verifiers.Attribute) (context.Context, verifiers.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttributes(attrs ...verifiers.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
+
+func convert(attrs []verifiers.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, len(attrs))
+	for index, attr := range attrs {
+		switch value := attr.Value.(type) {
+		case string:
+			kvs[index] = attribute.String(attr.Key, value)
+		case int:
+			kvs[index] = attribute.Int(attr.Key, value)
+		case int64:
+			kvs[index] = attribute.Int64(attr.Key, value)
+		case bool:
+			kvs[index] = attribute.Bool(attr.Key, value)
+		case float64:
+			kvs[index] = attribute.Float64(attr.Key, value)
+		default:
+			kvs[index] = attribute.String(attr.Key, fmt.Sprint(value))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,51 @@
+package otelverifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/otelverifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestNewTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	v := verifiers.New(context.Background(), verifiers.WithTracer(otelverifiers.NewTracer(provider.Tracer("test"))))
+
+	assert.NoError(t, v.OneOf(
+		verifiers.Named("db", func(ctx context.Context) error {
+			return nil
+		}),
+		verifiers.Named("cache", func(ctx context.Context) error {
+			return errors.New("some error")
+		}),
+	))
+
+	var parent sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "verifiers.one_of" {
+			parent = span
+		}
+	}
+	require.NotNil(t, parent)
+	assert.Contains(t, parent.Attributes(), attribute.String("verifiers.policy", "one_of"))
+	assert.Contains(t, parent.Attributes(), attribute.String("verifiers.outcome", "success"))
+	assert.Contains(t, parent.Attributes(), attribute.Int("verifiers.required", 1))
+
+	for _, span := range recorder.Ended() {
+		if span.Name() != "verifiers.verifier" {
+			continue
+		}
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		if span.Status().Code == codes.Error {
+			assert.Equal(t, "cache: some error", span.Status().Description)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package verifiers
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Plan is validated policy with verifiers which can be run many times concurrently
+type Plan struct {
+	name   string
+	policy Policy
+	fns    []Verifier
+	cache  *cache
+	flight *flight
+}
+
+// NewPlan validate policy against verifiers and return Plan.
+// Nested plans added with Plan.Verifier, they validated by own NewPlan
+func NewPlan(name string, p Policy, fns ...Verifier) (*Plan, error) {
+	if len(fns) == 0 {
+		return nil, fmt.Errorf("plan %s: %w", name, ErrNoVerifiers)
+	}
+	for index, fn := range fns {
+		if fn == nil {
+			return nil, fmt.Errorf("plan %s: %w: verifier %d is nil", name, ErrInvalidPolicy, index)
+		}
+	}
+	if err := p.validate(len(fns)); err != nil {
+		return nil, fmt.Errorf("plan %s: %w", name, err)
+	}
+	return &Plan{
+		name:   name,
+		policy: p,
+		fns:    append([]Verifier{}, fns...),
+	}, nil
+}
+
+// MustPlan is like NewPlan but panics if plan is invalid
+func MustPlan(name string, p Policy, fns ...Verifier) *Plan {
+	plan, err := NewPlan(name, p, fns...)
+	if err != nil {
+		panic(err)
+	}
+	return plan
+}
+
+// Name return name of plan
+func (p *Plan) Name() string {
+	return p.name
+}
+
+// Policy return policy of plan
+func (p *Plan) Policy() Policy {
+	return p.policy
+}
+
+// Cached return copy of plan which memoize report for ttl, see Cached.
+// Cached report shared between callers and can be verified with options of other caller
+func (p *Plan) Cached(ttl time.Duration, options ...CacheOption) *Plan {
+	plan := *p
+	plan.cache = newCache(ttl, options...)
+	return &plan
+}
+
+// Singleflight return copy of plan which share one verification between concurrent callers, see Singleflight.
+// Shared verification can be verified with options of other caller
+func (p *Plan) Singleflight() *Plan {
+	plan := *p
+	plan.flight = &flight{}
+	return &plan
+}
+
+// Run verify plan with verifier created by New with provided context and options
+func (p *Plan) Run(ctx context.Context, options ...option) (*Report, error) {
+	return p.verify(New(ctx, options...))
+}
+
+// Verifier return named verifier which verify plan, so plan can be nested into other plan.
+// Nested plan verified with options of verifier which call it
+func (p *Plan) Verifier() Verifier {
+	return Named(p.name, func(ctx context.Context) error {
+		_, err := p.verify(verifierFrom(ctx))
+		return err
+	})
+}
+
+func (p *Plan) verify(f *verifier) (*Report, error) {
+	if f.partition == "" {
+		f = f.with(f.ctx)
+		f.partition = p.name
+	}
+	load := func(ctx context.Context) (*Report, error) {
+		report := f.with(ctx).run(p.policy, p.fns...)
+		return report, report.Err
+	}
+	if p.flight != nil {
+		run := load
+		load = func(ctx context.Context) (*Report, error) {
+			return p.flight.do(ctx, run)
+		}
+	}
+	if p.cache != nil {
+		cached := load
+		load = func(ctx context.Context) (*Report, error) {
+			return p.cache.get(ctx, cached)
+		}
+	}
+	report, err := load(f.ctx)
+	// Context done while other caller verify plan
+	if report == nil {
+		report = &Report{Policy: p.policy, Required: p.policy.Required(len(p.fns)), Err: err}
+	}
+	return report, err
+}
+
+type verifierKey struct{}
+
+// verifierFrom return verifier which call function with provided context, new verifier if function called directly
+func verifierFrom(ctx context.Context) *verifier {
+	if f, ok := ctx.Value(verifierKey{}).(*verifier); ok {
+		return f.with(ctx)
+	}
+	return New(ctx)
+}
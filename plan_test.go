@@ -0,0 +1,71 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestNewPlan(t *testing.T) {
+	t.Run("Return: err - validation", func(t *testing.T) {
+		_, err := verifiers.NewPlan("empty", verifiers.All())
+		assert.True(t, errors.Is(err, verifiers.ErrNoVerifiers))
+		_, err = verifiers.NewPlan("count", verifiers.AtLeast(3), succeed, succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrCountMoreThanLength))
+		_, err = verifiers.NewPlan("negative", verifiers.Exact(-1), succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrNegativeCount))
+		assert.Contains(t, err.Error(), "plan negative")
+		_, err = verifiers.NewPlan("nil", verifiers.OneOf(), succeed, nil)
+		assert.True(t, errors.Is(err, verifiers.ErrInvalidPolicy))
+		_, err = verifiers.NewPlan("zero", verifiers.Policy{}, succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrInvalidPolicy))
+		assert.Panics(t, func() {
+			verifiers.MustPlan("empty", verifiers.All())
+		})
+	})
+	t.Run("Return: nil - valid plan", func(t *testing.T) {
+		plan, err := verifiers.NewPlan("health", verifiers.AtLeast(1), succeed, fail)
+		require.NoError(t, err)
+		assert.Equal(t, "health", plan.Name())
+		assert.Equal(t, "at_least(1)", plan.Policy().String())
+	})
+}
+
+func TestPlan_Run(t *testing.T) {
+	t.Run("Return: nil - concurrent runs", func(t *testing.T) {
+		plan := verifiers.MustPlan("health", verifiers.Exact(2), succeed, succeed, fail)
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				report, err := plan.Run(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, 2, report.Succeeded)
+			}()
+		}
+		wg.Wait()
+	})
+	t.Run("Return: err - nested plans with options", func(t *testing.T) {
+		zoneA := verifiers.MustPlan("zone-a", verifiers.OneOf(), fail, succeed)
+		zoneB := verifiers.MustPlan("zone-b", verifiers.OneOf(), fail, fail)
+		plan := verifiers.MustPlan("zones", verifiers.All(), zoneA.Verifier(), zoneB.Verifier())
+		metrics := &recordingMetrics{}
+		report, err := plan.Run(context.Background(), verifiers.WithMetrics(metrics))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		assert.Equal(t, "zone-b", report.Results[1].Name)
+		assert.Equal(t, verifiers.OutcomeFailure, report.Results[1].Outcome)
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		assert.Contains(t, metrics.verifications, "one_of:max_amount_of_error")
+		assert.Contains(t, metrics.verifications, "all:max_amount_of_error")
+	})
+	t.Run("Return: nil - nested plan called directly", func(t *testing.T) {
+		plan := verifiers.MustPlan("zone-a", verifiers.OneOf(), fail, succeed)
+		assert.NoError(t, plan.Verifier()(context.Background()))
+	})
+}
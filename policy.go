@@ -0,0 +1,93 @@
+package verifiers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrNegativeCount is configuration error, will return if policy expect negative amount of functions
+	ErrNegativeCount = errors.New("count can not be negative")
+	// ErrInvalidPolicy is configuration error, will return if policy or verifiers can not be verified
+	ErrInvalidPolicy = errors.New("invalid policy")
+)
+
+// Policy describes condition which verification should match
+type Policy struct {
+	name  string
+	count int
+	exact bool
+	// all mean count equal to amount of functions
+	all bool
+}
+
+// All policy match if all functions finished without error, see verifier.All
+func All() Policy {
+	return Policy{name: "all", exact: true, all: true}
+}
+
+// AtLeast policy match if at least count functions finished without error, see verifier.AtLeast
+func AtLeast(count int) Policy {
+	return Policy{name: "at_least", count: count}
+}
+
+// Exact policy match if exactly count functions finished without error, see verifier.Exact
+func Exact(count int) Policy {
+	return Policy{name: "exact", count: count, exact: true}
+}
+
+// OneOf policy match if at least one function finished without error, see verifier.OneOf
+func OneOf() Policy {
+	return Policy{name: "one_of", count: 1}
+}
+
+// OnlyOne policy match if exactly one function finished without error, see verifier.OnlyOne
+func OnlyOne() Policy {
+	return Policy{name: "only_one", count: 1, exact: true}
+}
+
+// NoOne policy match if no one function finished without error, see verifier.NoOne
+func NoOne() Policy {
+	return Policy{name: "no_one", exact: true}
+}
+
+// Name return name of policy, like "all" or "at_least"
+func (p Policy) Name() string {
+	return p.name
+}
+
+// Required return amount of functions which should finish without error from total amount
+func (p Policy) Required(total int) int {
+	if p.all {
+		return total
+	}
+	return p.count
+}
+
+// Exact return true if amount of functions finished without error should be exactly Required
+func (p Policy) Exact() bool {
+	return p.exact
+}
+
+// String return policy with count for policies which have it, like "at_least(2)"
+func (p Policy) String() string {
+	if p.name == "at_least" || p.name == "exact" {
+		return p.name + "(" + strconv.Itoa(p.count) + ")"
+	}
+	return p.name
+}
+
+// validate return error if policy can not be matched by provided amount of functions
+func (p Policy) validate(total int) error {
+	if p.name == "" {
+		return fmt.Errorf("%w: empty policy", ErrInvalidPolicy)
+	}
+	if p.count < 0 {
+		return ErrNegativeCount
+	}
+	if p.Required(total) > total {
+		return ErrCountMoreThanLength
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+type execProbe struct {
+	name     string
+	args     []string
+	dir      string
+	env      []string
+	exitCode int
+	pattern  *regexp.Regexp
+}
+
+// ExecOption configure command probe
+type ExecOption func(p *execProbe)
+
+// ExitCode expect exit code of command, 0 by default
+func ExitCode(code int) ExecOption {
+	return func(p *execProbe) {
+		p.exitCode = code
+	}
+}
+
+// OutputMatches expect combined stdout and stderr of command match regular expression
+func OutputMatches(pattern *regexp.Regexp) ExecOption {
+	return func(p *execProbe) {
+		p.pattern = pattern
+	}
+}
+
+// Dir is working directory of command
+func Dir(dir string) ExecOption {
+	return func(p *execProbe) {
+		p.dir = dir
+	}
+}
+
+// Env add "key=value" environment variables to environment of current process for command
+func Env(env ...string) ExecOption {
+	return func(p *execProbe) {
+		p.env = append(p.env, env...)
+	}
+}
+
+// Exec return verifier which run command and check exit code and output, command killed when context done
+func Exec(name string, args []string, options ...ExecOption) verifiers.Verifier {
+	p := &execProbe{name: name, args: args}
+	for _, opt := range options {
+		opt(p)
+	}
+	return named("exec", strings.Join(append([]string{name}, args...), " "), p.verify)
+}
+
+func (p *execProbe) verify(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, p.name, p.args...)
+	cmd.Dir = p.dir
+	if len(p.env) > 0 {
+		cmd.Env = append(os.Environ(), p.env...)
+	}
+	output, err := cmd.CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	exitCode := 0
+	if err != nil {
+		exitErr := &exec.ExitError{}
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+		exitCode = exitErr.ExitCode()
+	}
+	if exitCode != p.exitCode {
+		return fmt.Errorf("%w: exit code %d, expected %d", ErrMismatch, exitCode, p.exitCode)
+	}
+	if p.pattern != nil && !p.pattern.Match(output) {
+		return fmt.Errorf("%w: output not match %s", ErrMismatch, p.pattern)
+	}
+	return nil
+}
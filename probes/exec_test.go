@@ -0,0 +1,36 @@
+package probes_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers/probes"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestExec(t *testing.T) {
+	t.Run("Return: nil - expectations matched", func(t *testing.T) {
+		fn := probes.Exec("sh", []string{"-c", "echo $GREETING from $(pwd)"},
+			probes.Env("GREETING=hello"),
+			probes.Dir("/"),
+			probes.OutputMatches(regexp.MustCompile("^hello from /\n$")),
+		)
+		assert.Equal(t, "exec sh -c echo $GREETING from $(pwd)", fn.Name())
+		assert.NoError(t, fn(context.Background()))
+		assert.NoError(t, probes.Exec("sh", []string{"-c", "exit 3"}, probes.ExitCode(3))(context.Background()))
+	})
+	t.Run("Return: err - expectations not matched", func(t *testing.T) {
+		assert.True(t, errors.Is(probes.Exec("sh", []string{"-c", "exit 1"})(context.Background()), probes.ErrMismatch))
+		assert.True(t, errors.Is(probes.Exec("sh", []string{"-c", "echo failed"}, probes.OutputMatches(regexp.MustCompile("ok")))(context.Background()), probes.ErrMismatch))
+		assert.Error(t, probes.Exec("command-which-not-exist", nil)(context.Background()))
+	})
+	t.Run("Return: err - command killed when context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		startTime := time.Now()
+		assert.Equal(t, context.DeadlineExceeded, probes.Exec("sleep", []string{"5"})(ctx))
+		assert.True(t, time.Since(startTime) < time.Second*2)
+	})
+}
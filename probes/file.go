@@ -0,0 +1,77 @@
+package probes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+type fileProbe struct {
+	path     string
+	newHash  func() hash.Hash
+	checksum string
+}
+
+// FileOption configure file probe
+type FileOption func(p *fileProbe)
+
+// Checksum expect hex encoded checksum of file content calculated by hash
+func Checksum(newHash func() hash.Hash, sum string) FileOption {
+	return func(p *fileProbe) {
+		p.newHash = newHash
+		p.checksum = strings.ToLower(sum)
+	}
+}
+
+// SHA256 expect hex encoded SHA-256 checksum of file content
+func SHA256(sum string) FileOption {
+	return Checksum(sha256.New, sum)
+}
+
+// File return verifier which check file exists and match expectations
+func File(path string, options ...FileOption) verifiers.Verifier {
+	p := &fileProbe{path: path}
+	for _, opt := range options {
+		opt(p)
+	}
+	return named("file", path, p.verify)
+}
+
+func (p *fileProbe) verify(ctx context.Context) error {
+	if p.newHash == nil {
+		_, err := os.Stat(p.path)
+		return err
+	}
+	file, err := os.Open(p.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	h := p.newHash()
+	if _, err := io.Copy(h, &contextReader{ctx: ctx, reader: file}); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != p.checksum {
+		return fmt.Errorf("%w: checksum %s, expected %s", ErrMismatch, sum, p.checksum)
+	}
+	return nil
+}
+
+// contextReader stop reading of big files when context done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,27 @@
+package probes_test
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"github.com/PxyUp/verifiers/probes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release")
+	assert.True(t, errors.Is(probes.File(path)(context.Background()), os.ErrNotExist))
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	assert.NoError(t, probes.File(path)(context.Background()))
+	assert.NoError(t, probes.File(path, probes.SHA256("2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"))(context.Background()))
+	assert.NoError(t, probes.File(path, probes.Checksum(md5.New, "5d41402abc4b2a76b9719d911017c592"))(context.Background()))
+	assert.True(t, errors.Is(probes.File(path, probes.SHA256("00"))(context.Background()), probes.ErrMismatch))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, probes.File(path, probes.SHA256("00"))(ctx))
+}
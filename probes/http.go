@@ -0,0 +1,184 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxBodySize is limit of response body which read for expectations
+const maxBodySize = 1 << 20
+
+type httpProbe struct {
+	method   string
+	url      string
+	headers  http.Header
+	body     string
+	client   *http.Client
+	statuses []int
+	pattern  *regexp.Regexp
+	fields   []jsonField
+}
+
+type jsonField struct {
+	path     string
+	expected string
+}
+
+// HTTPOption configure HTTP probe
+type HTTPOption func(p *httpProbe)
+
+// Method of request, GET by default
+func Method(method string) HTTPOption {
+	return func(p *httpProbe) {
+		p.method = method
+	}
+}
+
+// Header add header to request
+func Header(key, value string) HTTPOption {
+	return func(p *httpProbe) {
+		p.headers.Add(key, value)
+	}
+}
+
+// RequestBody of request
+func RequestBody(body string) HTTPOption {
+	return func(p *httpProbe) {
+		p.body = body
+	}
+}
+
+// Client used for request, http.DefaultClient by default
+func Client(client *http.Client) HTTPOption {
+	return func(p *httpProbe) {
+		p.client = client
+	}
+}
+
+// Status expect one of provided status codes, any 2xx status by default
+func Status(codes ...int) HTTPOption {
+	return func(p *httpProbe) {
+		p.statuses = append(p.statuses, codes...)
+	}
+}
+
+// BodyMatches expect response body match regular expression
+func BodyMatches(pattern *regexp.Regexp) HTTPOption {
+	return func(p *httpProbe) {
+		p.pattern = pattern
+	}
+}
+
+// JSONField expect field of JSON response by dot separated path(like "data.items.0.state") equal to expected value
+func JSONField(path string, expected interface{}) HTTPOption {
+	return func(p *httpProbe) {
+		p.fields = append(p.fields, jsonField{path: path, expected: fmt.Sprint(expected)})
+	}
+}
+
+// HTTP return verifier which send request to url and check response match expectations, labeled with host of url
+func HTTP(url string, options ...HTTPOption) verifiers.Verifier {
+	p := &httpProbe{
+		method:  http.MethodGet,
+		url:     url,
+		headers: http.Header{},
+		client:  http.DefaultClient,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	return named("http", p.method+" "+url, p.verify, urlHostLabel(url)...)
+}
+
+func (p *httpProbe) verify(ctx context.Context) error {
+	var body io.Reader
+	if p.body != "" {
+		body = strings.NewReader(p.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, p.method, p.url, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range p.headers {
+		req.Header[key] = values
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !p.expectedStatus(resp.StatusCode) {
+		return fmt.Errorf("%w: status %d", ErrMismatch, resp.StatusCode)
+	}
+	if p.pattern == nil && len(p.fields) == 0 {
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+	if p.pattern != nil && !p.pattern.Match(data) {
+		return fmt.Errorf("%w: body not match %s", ErrMismatch, p.pattern)
+	}
+	if len(p.fields) == 0 {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("%w: body is not JSON: %s", ErrMismatch, err)
+	}
+	for _, field := range p.fields {
+		value, ok := lookup(document, field.path)
+		if !ok {
+			return fmt.Errorf("%w: field %s not found", ErrMismatch, field.path)
+		}
+		if actual := fmt.Sprint(value); actual != field.expected {
+			return fmt.Errorf("%w: field %s is %q, expected %q", ErrMismatch, field.path, actual, field.expected)
+		}
+	}
+	return nil
+}
+
+func (p *httpProbe) expectedStatus(code int) bool {
+	if len(p.statuses) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, status := range p.statuses {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup return value of decoded JSON by dot separated path
+func lookup(document interface{}, path string) (interface{}, bool) {
+	value := document
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
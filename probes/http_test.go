@@ -0,0 +1,98 @@
+package probes_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/probes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTP(t *testing.T) {
+	var busy, maxBusy int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/busy":
+			current := atomic.AddInt32(&busy, 1)
+			defer atomic.AddInt32(&busy, -1)
+			for {
+				max := atomic.LoadInt32(&maxBusy)
+				if current <= max || atomic.CompareAndSwapInt32(&maxBusy, max, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 20)
+		case "/healthz":
+			if r.Header.Get("Authorization") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"status": "ok", "checks": [{"name": "db", "healthy": true}], "replicas": 3}`)
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write(append([]byte(r.Method+" "), body...))
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second * 5):
+			}
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Return: nil - expectations matched", func(t *testing.T) {
+		fn := probes.HTTP(server.URL+"/healthz",
+			probes.Header("Authorization", "token"),
+			probes.Status(http.StatusOK),
+			probes.BodyMatches(regexp.MustCompile(`"status":\s*"ok"`)),
+			probes.JSONField("status", "ok"),
+			probes.JSONField("checks.0.healthy", true),
+			probes.JSONField("replicas", 3),
+		)
+		assert.Equal(t, "http GET "+server.URL+"/healthz", fn.Name())
+		assert.Equal(t, []verifiers.Label{{Key: "probe", Value: "http"}, {Key: "host", Value: "127.0.0.1"}}, fn.Labels())
+		assert.NoError(t, fn(context.Background()))
+		assert.NoError(t, probes.HTTP(server.URL+"/echo",
+			probes.Method(http.MethodPost),
+			probes.RequestBody("ping"),
+			probes.BodyMatches(regexp.MustCompile("^POST ping$")),
+			probes.Client(server.Client()),
+		)(context.Background()))
+	})
+	t.Run("Return: err - expectations not matched", func(t *testing.T) {
+		for _, fn := range []verifiers.Verifier{
+			probes.HTTP(server.URL + "/unknown"),
+			probes.HTTP(server.URL+"/healthz", probes.Header("Authorization", "token"), probes.Status(http.StatusNoContent)),
+			probes.HTTP(server.URL+"/healthz", probes.Header("Authorization", "token"), probes.BodyMatches(regexp.MustCompile("failed"))),
+			probes.HTTP(server.URL+"/healthz", probes.Header("Authorization", "token"), probes.JSONField("status", "failed")),
+			probes.HTTP(server.URL+"/healthz", probes.Header("Authorization", "token"), probes.JSONField("checks.1.name", "db")),
+			probes.HTTP(server.URL+"/echo", probes.JSONField("status", "ok")),
+		} {
+			assert.True(t, errors.Is(fn(context.Background()), probes.ErrMismatch), fn.Name())
+		}
+		assert.Error(t, probes.HTTP("http://127.0.0.1:1")(context.Background()))
+	})
+	t.Run("Return: nil - probes limited per host", func(t *testing.T) {
+		v := verifiers.New(context.Background(), verifiers.WithConcurrencyLimit("host", 1))
+		assert.NoError(t, v.All(probes.HTTP(server.URL+"/busy"), probes.HTTP(server.URL+"/busy"), probes.HTTP(server.URL+"/busy")))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&maxBusy))
+	})
+	t.Run("Return: err - context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		startTime := time.Now()
+		assert.True(t, errors.Is(probes.HTTP(server.URL+"/slow")(ctx), context.DeadlineExceeded))
+		assert.True(t, time.Since(startTime) < time.Second)
+	})
+}
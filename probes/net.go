@@ -0,0 +1,28 @@
+package probes
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"net"
+)
+
+// TCP return verifier which check TCP connection to address can be established, labeled with host of address
+func TCP(address string) verifiers.Verifier {
+	return named("tcp", address, dial("tcp", address), hostLabel(address)...)
+}
+
+// Unix return verifier which check connection to UNIX socket can be established
+func Unix(path string) verifiers.Verifier {
+	return named("unix", path, dial("unix", path))
+}
+
+func dial(network, address string) verifiers.Verifier {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, network, address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
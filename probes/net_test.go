@@ -0,0 +1,34 @@
+package probes_test
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/probes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	fn := probes.TCP(address)
+	assert.Equal(t, "tcp "+address, fn.Name())
+	assert.Equal(t, []verifiers.Label{{Key: "probe", Value: "tcp"}, {Key: "host", Value: "127.0.0.1"}}, fn.Labels())
+	assert.NoError(t, fn(context.Background()))
+	require.NoError(t, listener.Close())
+	assert.Error(t, fn(context.Background()))
+}
+
+func TestUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "probe.sock")
+	fn := probes.Unix(path)
+	assert.Error(t, fn(context.Background()))
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+	assert.NoError(t, fn(context.Background()))
+}
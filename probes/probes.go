@@ -0,0 +1,39 @@
+// Package probes provide verifiers for common checks: HTTP request, TCP and UNIX socket connect, file and command execution.
+// All probes honor context of verifier and return named verifiers with "probe" label,
+// network probes also labeled with "host", so they can be limited by verifiers.WithConcurrencyLimit("host", n)
+package probes
+
+import (
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"net"
+	"net/url"
+)
+
+// ErrMismatch wrapped by errors of probes when result not match expectation
+var ErrMismatch = errors.New("probe result mismatch")
+
+func named(probe string, name string, fn verifiers.Verifier, labels ...verifiers.Label) verifiers.Verifier {
+	return verifiers.Named(probe+" "+name, fn, append([]verifiers.Label{{Key: "probe", Value: probe}}, labels...)...)
+}
+
+// hostLabel return "host" label with host of address without port, no label if host unknown
+func hostLabel(address string) []verifiers.Label {
+	host := address
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+	if host == "" {
+		return nil
+	}
+	return []verifiers.Label{{Key: "host", Value: host}}
+}
+
+// urlHostLabel return "host" label with host of URL without port, no label if URL invalid
+func urlHostLabel(rawURL string) []verifiers.Label {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	return hostLabel(u.Hostname())
+}
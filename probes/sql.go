@@ -0,0 +1,156 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type sqlProbe struct {
+	db     *sql.DB
+	query  string
+	args   []interface{}
+	rows   int
+	scalar interface{}
+	maxLag time.Duration
+	// expectations which should be checked
+	checkRows, checkScalar, checkLag bool
+}
+
+// SQLOption configure database probe
+type SQLOption func(p *sqlProbe)
+
+// Args of query
+func Args(args ...interface{}) SQLOption {
+	return func(p *sqlProbe) {
+		p.args = args
+	}
+}
+
+// Rows expect query return exactly count rows
+func Rows(count int) SQLOption {
+	return func(p *sqlProbe) {
+		p.rows, p.checkRows = count, true
+	}
+}
+
+// Scalar expect first column of first row equal to expected value(compared as strings)
+func Scalar(expected interface{}) SQLOption {
+	return func(p *sqlProbe) {
+		p.scalar, p.checkScalar = expected, true
+	}
+}
+
+// MaxLag expect replication lag returned by query less than max.
+// First column of first row is lag in seconds, duration string like "1.5s" or time of last replicated transaction
+func MaxLag(max time.Duration) SQLOption {
+	return func(p *sqlProbe) {
+		p.maxLag, p.checkLag = max, true
+	}
+}
+
+// SQL return verifier which run query on db and check result match expectations, db pinged if query is empty
+func SQL(db *sql.DB, query string, options ...SQLOption) verifiers.Verifier {
+	p := &sqlProbe{db: db, query: query}
+	for _, opt := range options {
+		opt(p)
+	}
+	name := query
+	if name == "" {
+		name = "ping"
+	}
+	return named("sql", name, p.verify)
+}
+
+func (p *sqlProbe) verify(ctx context.Context) error {
+	if p.query == "" {
+		return p.db.PingContext(ctx)
+	}
+	rows, err := p.db.QueryContext(ctx, p.query, p.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	var first interface{}
+	for rows.Next() {
+		if count == 0 && (p.checkScalar || p.checkLag) {
+			columns, err := rows.Columns()
+			if err != nil {
+				return err
+			}
+			values := make([]interface{}, len(columns))
+			pointers := make([]interface{}, len(columns))
+			for index := range values {
+				pointers[index] = &values[index]
+			}
+			if err := rows.Scan(pointers...); err != nil {
+				return err
+			}
+			if len(values) > 0 {
+				first = values[0]
+			}
+		}
+		count += 1
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if p.checkRows && count != p.rows {
+		return fmt.Errorf("%w: %d rows, expected %d", ErrMismatch, count, p.rows)
+	}
+	if (p.checkScalar || p.checkLag) && count == 0 {
+		return fmt.Errorf("%w: query return no rows", ErrMismatch)
+	}
+	if p.checkScalar {
+		if actual, expected := scalarString(first), scalarString(p.scalar); actual != expected {
+			return fmt.Errorf("%w: value %q, expected %q", ErrMismatch, actual, expected)
+		}
+	}
+	if p.checkLag {
+		lag, err := replicationLag(first)
+		if err != nil {
+			return err
+		}
+		if lag >= p.maxLag {
+			return fmt.Errorf("%w: replication lag %s, expected less than %s", ErrMismatch, lag, p.maxLag)
+		}
+	}
+	return nil
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case nil:
+		return "NULL"
+	}
+	return fmt.Sprint(value)
+}
+
+func replicationLag(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return time.Since(v), nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case []byte, string:
+		s := strings.TrimSpace(scalarString(v))
+		if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second)), nil
+		}
+		if lag, err := time.ParseDuration(s); err == nil {
+			return lag, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: can not read replication lag from %q", ErrMismatch, scalarString(value))
+}
@@ -0,0 +1,140 @@
+package probes_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/probes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDriver return rows from tables registered by DSN, query is name of table
+type fakeDriver struct{}
+
+var fakeDatabases = map[string]map[string][][]driver.Value{}
+
+func init() {
+	sql.Register("probes-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	tables, ok := fakeDatabases[dsn]
+	if !ok {
+		return nil, errors.New("database is down")
+	}
+	return &fakeConn{tables: tables}, nil
+}
+
+type fakeConn struct {
+	tables map[string][][]driver.Value
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if query == "slow" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	table, ok := c.tables[query]
+	if !ok {
+		return nil, errors.New("unknown table " + query)
+	}
+	return &fakeRows{rows: table}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openFake(t *testing.T, dsn string, tables map[string][][]driver.Value) *sql.DB {
+	if tables != nil {
+		fakeDatabases[dsn] = tables
+	}
+	db, err := sql.Open("probes-fake", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+func TestSQL(t *testing.T) {
+	primary := openFake(t, "primary", map[string][][]driver.Value{
+		"users":   {{int64(1)}, {int64(2)}},
+		"version": {{[]byte("15.2")}},
+		"empty":   {},
+	})
+	t.Run("Return: nil - expectations matched", func(t *testing.T) {
+		fn := probes.SQL(primary, "users", probes.Rows(2), probes.Scalar(1))
+		assert.Equal(t, "sql users", fn.Name())
+		assert.NoError(t, fn(context.Background()))
+		assert.NoError(t, probes.SQL(primary, "version", probes.Scalar("15.2"), probes.Args(1))(context.Background()))
+		assert.NoError(t, probes.SQL(primary, "")(context.Background()))
+	})
+	t.Run("Return: err - expectations not matched", func(t *testing.T) {
+		for _, fn := range []verifiers.Verifier{
+			probes.SQL(primary, "users", probes.Rows(1)),
+			probes.SQL(primary, "version", probes.Scalar("16")),
+			probes.SQL(primary, "empty", probes.Scalar(1)),
+			probes.SQL(primary, "version", probes.MaxLag(time.Second)),
+		} {
+			assert.True(t, errors.Is(fn(context.Background()), probes.ErrMismatch), fn.Name())
+		}
+		assert.Error(t, probes.SQL(primary, "unknown")(context.Background()))
+		assert.Error(t, probes.SQL(openFake(t, "down", nil), "")(context.Background()))
+	})
+	t.Run("Return: err - context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		assert.True(t, errors.Is(probes.SQL(primary, "slow")(ctx), context.DeadlineExceeded))
+	})
+	t.Run("Return: nil - at least 2 of 3 replicas with small lag", func(t *testing.T) {
+		const lag = "SELECT lag"
+		replicas := []verifiers.Verifier{
+			probes.SQL(openFake(t, "replica-1", map[string][][]driver.Value{lag: {{float64(0.5)}}}), lag, probes.MaxLag(time.Second*5)),
+			probes.SQL(openFake(t, "replica-2", map[string][][]driver.Value{lag: {{time.Now().Add(-time.Second)}}}), lag, probes.MaxLag(time.Second*5)),
+			probes.SQL(openFake(t, "replica-3", map[string][][]driver.Value{lag: {{[]byte("12s")}}}), lag, probes.MaxLag(time.Second*5)),
+		}
+		v := verifiers.New(context.Background())
+		report, err := v.Run(verifiers.AtLeast(2), replicas...)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Succeeded)
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(replicas...))
+		assert.True(t, strings.HasPrefix(replicas[2].Name(), "sql SELECT"))
+	})
+}
@@ -0,0 +1,209 @@
+// Package promverifiers expose verifiers.Metrics in Prometheus/OpenMetrics text format without dependency on Prometheus client
+package promverifiers
+
+import (
+	"fmt"
+	"github.com/PxyUp/verifiers"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ContentTypePrometheus is content type of Prometheus text exposition format
+	ContentTypePrometheus = "text/plain; version=0.0.4; charset=utf-8"
+	// ContentTypeOpenMetrics is content type of OpenMetrics text exposition format
+	ContentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// DefaultBuckets used for latency histograms if buckets not provided to New
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var _ verifiers.Metrics = (*Metrics)(nil)
+
+// Metrics implements verifiers.Metrics and http.Handler which render collected values
+type Metrics struct {
+	mu            sync.Mutex
+	namespace     string
+	buckets       []float64
+	verifications map[series]float64
+	verification  map[series]*histogram
+	verifier      map[series]*histogram
+	inFlight      map[series]float64
+}
+
+// series is label set of single metric, outcome is empty for metrics without outcome label
+type series struct {
+	policy  string
+	outcome string
+}
+
+func (s series) labels() string {
+	if s.outcome == "" {
+		return fmt.Sprintf("policy=%q", s.policy)
+	}
+	return fmt.Sprintf("policy=%q,outcome=%q", s.policy, s.outcome)
+}
+
+func (s series) less(other series) bool {
+	if s.policy != other.policy {
+		return s.policy < other.policy
+	}
+	return s.outcome < other.outcome
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// New return Metrics with provided namespace(prefix of metric names, "verifiers" if empty) and latency buckets(DefaultBuckets if empty)
+func New(namespace string, buckets ...float64) *Metrics {
+	if namespace == "" {
+		namespace = "verifiers"
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &Metrics{
+		namespace:     namespace,
+		buckets:       sorted,
+		verifications: map[series]float64{},
+		verification:  map[series]*histogram{},
+		verifier:      map[series]*histogram{},
+		inFlight:      map[series]float64{},
+	}
+}
+
+// VerificationFinished implements verifiers.Metrics
+func (m *Metrics) VerificationFinished(policy, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifications[series{policy: policy, outcome: outcome}] += 1
+	m.observe(m.verification, series{policy: policy}, duration)
+}
+
+// VerifierFinished implements verifiers.Metrics
+func (m *Metrics) VerifierFinished(policy, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observe(m.verifier, series{policy: policy, outcome: outcome}, duration)
+}
+
+// InFlight implements verifiers.Metrics
+func (m *Metrics) InFlight(policy string, delta int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[series{policy: policy}] += float64(delta)
+}
+
+func (m *Metrics) observe(histograms map[series]*histogram, key series, duration time.Duration) {
+	h, ok := histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		histograms[key] = h
+	}
+	seconds := duration.Seconds()
+	for index, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[index] += 1
+		}
+	}
+	h.count += 1
+	h.sum += seconds
+}
+
+// ServeHTTP render metrics in OpenMetrics format if client accept it, otherwise in Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", ContentTypeOpenMetrics)
+	} else {
+		w.Header().Set("Content-Type", ContentTypePrometheus)
+	}
+	_ = m.write(w, openMetrics)
+}
+
+// WritePrometheus write metrics in Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	return m.write(w, false)
+}
+
+// WriteOpenMetrics write metrics in OpenMetrics text exposition format
+func (m *Metrics) WriteOpenMetrics(w io.Writer) error {
+	return m.write(w, true)
+}
+
+func (m *Metrics) write(w io.Writer, openMetrics bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := &strings.Builder{}
+
+	// OpenMetrics name counter family without _total suffix
+	name := m.namespace + "_verifications"
+	family := name
+	if !openMetrics {
+		family += "_total"
+	}
+	fmt.Fprintf(b, "# HELP %s Total number of verifications by policy and outcome.\n", family)
+	fmt.Fprintf(b, "# TYPE %s counter\n", family)
+	for _, key := range counterKeys(m.verifications) {
+		fmt.Fprintf(b, "%s_total{%s} %s\n", name, key.labels(), formatFloat(m.verifications[key]))
+	}
+
+	m.writeHistograms(b, m.namespace+"_verification_duration_seconds", "Duration of verifications by policy.", m.verification)
+	m.writeHistograms(b, m.namespace+"_verifier_duration_seconds", "Duration of single verifier by policy and outcome.", m.verifier)
+
+	name = m.namespace + "_verifiers_in_flight"
+	fmt.Fprintf(b, "# HELP %s Number of verifiers in flight by policy.\n", name)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	for _, key := range counterKeys(m.inFlight) {
+		fmt.Fprintf(b, "%s{%s} %s\n", name, key.labels(), formatFloat(m.inFlight[key]))
+	}
+
+	if openMetrics {
+		b.WriteString("# EOF\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (m *Metrics) writeHistograms(b *strings.Builder, name, help string, histograms map[series]*histogram) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s histogram\n", name)
+	keys := make([]series, 0, len(histograms))
+	for key := range histograms {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	for _, key := range keys {
+		h, labels := histograms[key], key.labels()
+		for index, bound := range m.buckets {
+			fmt.Fprintf(b, "%s_bucket{%s,le=%q} %d\n", name, labels, formatFloat(bound), h.counts[index])
+		}
+		fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(b, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+}
+
+func counterKeys(values map[series]float64) []series {
+	keys := make([]series, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	return keys
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,96 @@
+package promverifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/PxyUp/verifiers/promverifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scrape(t *testing.T, url string, accept string) (string, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := promverifiers.New("", 0.1, 1)
+	server := httptest.NewServer(metrics)
+	defer server.Close()
+
+	v := verifiers.New(context.Background(), verifiers.WithMetrics(metrics))
+	assert.NoError(t, v.All(
+		func(ctx context.Context) error {
+			return nil
+		},
+		func(ctx context.Context) error {
+			return nil
+		},
+	))
+	assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(
+		func(ctx context.Context) error {
+			return errors.New("some error")
+		},
+	))
+	time.Sleep(time.Millisecond * 50)
+
+	t.Run("Prometheus text format", func(t *testing.T) {
+		contentType, body := scrape(t, server.URL, "")
+		assert.Equal(t, promverifiers.ContentTypePrometheus, contentType)
+		assert.Contains(t, body, "# TYPE verifiers_verifications_total counter\n")
+		assert.Contains(t, body, "verifiers_verifications_total{policy=\"all\",outcome=\"success\"} 1\n")
+		assert.Contains(t, body, "verifiers_verifications_total{policy=\"all\",outcome=\"max_amount_of_error\"} 1\n")
+		assert.Contains(t, body, "verifiers_verification_duration_seconds_bucket{policy=\"all\",le=\"0.1\"} 2\n")
+		assert.Contains(t, body, "verifiers_verification_duration_seconds_count{policy=\"all\"} 2\n")
+		assert.Contains(t, body, "verifiers_verifier_duration_seconds_count{policy=\"all\",outcome=\"success\"} 2\n")
+		assert.Contains(t, body, "verifiers_verifier_duration_seconds_bucket{policy=\"all\",outcome=\"error\",le=\"+Inf\"} 1\n")
+		assert.Contains(t, body, "verifiers_verifiers_in_flight{policy=\"all\"} 0\n")
+		assert.False(t, strings.Contains(body, "# EOF"))
+	})
+
+	t.Run("OpenMetrics text format", func(t *testing.T) {
+		contentType, body := scrape(t, server.URL, "application/openmetrics-text; version=1.0.0")
+		assert.Equal(t, promverifiers.ContentTypeOpenMetrics, contentType)
+		assert.Contains(t, body, "# TYPE verifiers_verifications counter\n")
+		assert.Contains(t, body, "verifiers_verifications_total{policy=\"all\",outcome=\"success\"} 1\n")
+		assert.True(t, strings.HasSuffix(body, "# EOF\n"))
+	})
+}
+
+func TestMetrics_InFlight(t *testing.T) {
+	metrics := promverifiers.New("health")
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- verifiers.New(context.Background(), verifiers.WithMetrics(metrics)).OneOf(
+			func(ctx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			},
+		)
+	}()
+	<-started
+	b := &strings.Builder{}
+	require.NoError(t, metrics.WritePrometheus(b))
+	assert.Contains(t, b.String(), "health_verifiers_in_flight{policy=\"one_of\"} 1\n")
+	close(release)
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,198 @@
+package verifiers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDuplicateName will be returned by Registry if verifier with same name already registered
+	ErrDuplicateName = errors.New("verifier with same name already registered")
+	// ErrNoVerifiers will be returned if nothing to verify, for example selector not match any verifier in Registry
+	ErrNoVerifiers = errors.New("no verifiers to verify")
+)
+
+// Registry contains named verifiers with tags, which can be selected and verified by tag selector
+type Registry struct {
+	mu        sync.RWMutex
+	verifiers []Verifier
+	names     map[string]int
+}
+
+// NewRegistry return empty Registry
+func NewRegistry() *Registry {
+	return &Registry{names: map[string]int{}}
+}
+
+// Register add verifier with provided name and tags, tags used as labels of verifier
+func (r *Registry) Register(name string, fn Verifier, tags ...Label) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.names[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateName, name)
+	}
+	_, labels := Describe(fn)
+	r.names[name] = len(r.verifiers)
+	r.verifiers = append(r.verifiers, Named(name, fn, append(append([]Label{}, labels...), tags...)...))
+	return nil
+}
+
+// RegisterCheck add check with name of it and provided tags
+func (r *Registry) RegisterCheck(c Check, tags ...Label) error {
+	return r.Register(c.Name(), FromCheck(c), tags...)
+}
+
+// Get return verifier by name
+func (r *Registry) Get(name string) (Verifier, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	index, ok := r.names[name]
+	if !ok {
+		return nil, false
+	}
+	return r.verifiers[index], true
+}
+
+// Names return sorted names of all registered verifiers
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Select return verifiers which tags match selector in order of registration, see ParseSelector
+func (r *Registry) Select(selector string) ([]Verifier, error) {
+	s, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var fns []Verifier
+	for _, fn := range r.verifiers {
+		if s.Matches(fn.Labels()) {
+			fns = append(fns, fn)
+		}
+	}
+	return fns, nil
+}
+
+// Run verify verifiers selected by selector match policy, use Report.ByLabel for group results by tag.
+// Will return ErrNoVerifiers if selector not match any verifier
+func (r *Registry) Run(ctx context.Context, p Policy, selector string, options ...option) (*Report, error) {
+	fns, err := r.Select(selector)
+	if err != nil {
+		return &Report{Policy: p, Err: err}, err
+	}
+	if len(fns) == 0 {
+		return &Report{Policy: p, Err: ErrNoVerifiers}, ErrNoVerifiers
+	}
+	return New(ctx, options...).Run(p, fns...)
+}
+
+type operator string
+
+const (
+	operatorEquals    operator = "="
+	operatorNotEquals operator = "!="
+	operatorExists    operator = "exists"
+	operatorNotExists operator = "!exists"
+)
+
+type requirement struct {
+	key      string
+	operator operator
+	value    string
+}
+
+func (r requirement) matches(labels []Label) bool {
+	for _, label := range labels {
+		if label.Key != r.key {
+			continue
+		}
+		switch r.operator {
+		case operatorEquals:
+			if label.Value == r.value {
+				return true
+			}
+		case operatorNotEquals:
+			if label.Value == r.value {
+				return false
+			}
+		case operatorExists:
+			return true
+		case operatorNotExists:
+			return false
+		}
+	}
+	return r.operator == operatorNotEquals || r.operator == operatorNotExists
+}
+
+func (r requirement) String() string {
+	switch r.operator {
+	case operatorExists:
+		return r.key
+	case operatorNotExists:
+		return "!" + r.key
+	}
+	return r.key + string(r.operator) + r.value
+}
+
+// Selector select verifiers by tags, all requirements of selector should match
+type Selector struct {
+	requirements []requirement
+}
+
+// ParseSelector parse comma separated requirements: "key=value", "key!=value", "key"(tag exists), "!key"(tag not exists).
+// Empty selector match everything
+func ParseSelector(selector string) (Selector, error) {
+	var s Selector
+	if strings.TrimSpace(selector) == "" {
+		return s, nil
+	}
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		var r requirement
+		if key, value, ok := strings.Cut(part, "!="); ok {
+			r = requirement{key: strings.TrimSpace(key), operator: operatorNotEquals, value: strings.TrimSpace(value)}
+		} else if key, value, ok := strings.Cut(part, "="); ok {
+			r = requirement{key: strings.TrimSpace(key), operator: operatorEquals, value: strings.TrimSpace(strings.TrimPrefix(value, "="))}
+		} else if strings.HasPrefix(part, "!") {
+			r = requirement{key: strings.TrimSpace(part[1:]), operator: operatorNotExists}
+		} else {
+			r = requirement{key: part, operator: operatorExists}
+		}
+		if r.key == "" || strings.ContainsAny(r.key, "=! ") || strings.ContainsAny(r.value, "=! ") {
+			return Selector{}, fmt.Errorf("invalid selector %q: bad requirement %q", selector, part)
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+// Matches return true if labels match all requirements of selector
+func (s Selector) Matches(labels []Label) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	parts := make([]string, len(s.requirements))
+	for index, r := range s.requirements {
+		parts[index] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,101 @@
+package verifiers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func tags(pairs ...string) []verifiers.Label {
+	labels := make([]verifiers.Label, 0, len(pairs)/2)
+	for index := 0; index+1 < len(pairs); index += 2 {
+		labels = append(labels, verifiers.Label{Key: pairs[index], Value: pairs[index+1]})
+	}
+	return labels
+}
+
+func succeed(ctx context.Context) error {
+	return nil
+}
+
+func fail(ctx context.Context) error {
+	return someError
+}
+
+func newTestRegistry(t *testing.T) *verifiers.Registry {
+	registry := verifiers.NewRegistry()
+	require.NoError(t, registry.Register("db-primary", succeed, tags("kind", "db", "env", "prod")...))
+	require.NoError(t, registry.Register("db-replica", succeed, tags("kind", "db", "env", "prod", "tier", "canary")...))
+	require.NoError(t, registry.Register("cdn-eu", fail, tags("kind", "cdn", "env", "prod")...))
+	require.NoError(t, registry.Register("cdn-us", succeed, tags("kind", "cdn", "env", "prod")...))
+	require.NoError(t, registry.Register("cdn-staging", fail, tags("kind", "cdn", "env", "staging")...))
+	return registry
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Register: duplicate name", func(t *testing.T) {
+		registry := newTestRegistry(t)
+		assert.True(t, errors.Is(registry.Register("db-primary", succeed), verifiers.ErrDuplicateName))
+		assert.Equal(t, []string{"cdn-eu", "cdn-staging", "cdn-us", "db-primary", "db-replica"}, registry.Names())
+		fn, ok := registry.Get("cdn-us")
+		assert.True(t, ok)
+		assert.Equal(t, "cdn-us", fn.Name())
+		_, ok = registry.Get("unknown")
+		assert.False(t, ok)
+	})
+	t.Run("Select: by tags", func(t *testing.T) {
+		registry := newTestRegistry(t)
+		names := func(selector string) []string {
+			fns, err := registry.Select(selector)
+			require.NoError(t, err)
+			var result []string
+			for _, fn := range fns {
+				result = append(result, fn.Name())
+			}
+			return result
+		}
+		assert.Equal(t, []string{"db-primary", "db-replica"}, names("kind=db"))
+		assert.Equal(t, []string{"db-primary"}, names("env=prod, kind==db, tier!=canary"))
+		assert.Equal(t, []string{"db-replica"}, names("tier"))
+		assert.Equal(t, []string{"db-primary", "cdn-eu", "cdn-us", "cdn-staging"}, names("!tier"))
+		assert.Len(t, names(""), 5)
+		_, err := registry.Select("env=prod,=db")
+		assert.Error(t, err)
+	})
+	t.Run("Run: policy over selector", func(t *testing.T) {
+		registry := newTestRegistry(t)
+		report, err := registry.Run(context.Background(), verifiers.All(), "kind=db")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Succeeded)
+
+		_, err = registry.Run(context.Background(), verifiers.OneOf(), "kind=cdn,env=prod")
+		assert.NoError(t, err)
+
+		report, err = registry.Run(context.Background(), verifiers.All(), "env")
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		groups := report.ByLabel("kind")
+		assert.Len(t, groups["db"], 2)
+		assert.Len(t, groups["cdn"], 3)
+		assert.Equal(t, "cdn-eu", groups["cdn"][0].Name)
+
+		_, err = registry.Run(context.Background(), verifiers.All(), "kind=queue")
+		assert.Equal(t, verifiers.ErrNoVerifiers, err)
+	})
+}
+
+func TestParseSelector(t *testing.T) {
+	selector, err := verifiers.ParseSelector("env=prod,tier!=canary,zone,!maintenance")
+	require.NoError(t, err)
+	assert.Equal(t, "env=prod,tier!=canary,zone,!maintenance", selector.String())
+	assert.True(t, selector.Matches(tags("env", "prod", "zone", "a")))
+	assert.False(t, selector.Matches(tags("env", "prod", "zone", "a", "tier", "canary")))
+	assert.False(t, selector.Matches(tags("env", "prod", "zone", "a", "maintenance", "true")))
+	assert.False(t, selector.Matches(tags("env", "prod")))
+	for _, invalid := range []string{"=prod", "env=pr od", "!", "a,,b"} {
+		_, err := verifiers.ParseSelector(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,71 @@
+package verifiers
+
+import "time"
+
+// Outcome is result of single function in Report
+type Outcome string
+
+const (
+	// OutcomeSuccess function finished without error
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure function finished with error
+	OutcomeFailure Outcome = "failure"
+	// OutcomeCanceled function not finished before decision of verification was made
+	OutcomeCanceled Outcome = "canceled"
+)
+
+// Result of single function in Report
+type Result struct {
+	Name     string
+	Labels   []Label
+	Outcome  Outcome
+	Err      error
+	Duration time.Duration
+}
+
+// Label return value of label with provided key
+func (r Result) Label(key string) (string, bool) {
+	for _, label := range r.Labels {
+		if label.Key == key {
+			return label.Value, true
+		}
+	}
+	return "", false
+}
+
+// Report is detailed result of verification
+type Report struct {
+	// Policy which verification checked against
+	Policy Policy
+	// Required amount of functions which should finish without error
+	Required int
+	// Succeeded amount of functions finished without error before decision
+	Succeeded int
+	// Failed amount of functions finished with error before decision
+	Failed int
+	// Results of functions in same order as functions provided
+	Results  []Result
+	Duration time.Duration
+	// Err is decision of verification, nil if policy matched
+	Err error
+}
+
+// Passed return true if policy matched
+func (r *Report) Passed() bool {
+	return r.Err == nil
+}
+
+// Outcome return short name of decision, like "success" or "max_amount_of_error"
+func (r *Report) Outcome() string {
+	return outcome(r.Err)
+}
+
+// ByLabel group results by value of label with provided key, results without such label grouped under empty value
+func (r *Report) ByLabel(key string) map[string][]Result {
+	groups := map[string][]Result{}
+	for _, result := range r.Results {
+		value, _ := result.Label(key)
+		groups[value] = append(groups[value], result)
+	}
+	return groups
+}
@@ -0,0 +1,135 @@
+package verifiers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Window is how long policy should be matched continuously by Stable
+type Window struct {
+	rounds   int
+	duration time.Duration
+}
+
+// Rounds window is matched when policy matched in count consecutive rounds
+func Rounds(count int) Window {
+	return Window{rounds: count}
+}
+
+// HoldFor window is matched when policy matched continuously for duration,
+// from start of first round of streak until end of last one
+func HoldFor(duration time.Duration) Window {
+	return Window{duration: duration}
+}
+
+// String return window like "rounds(3)" or "hold_for(2m0s)"
+func (w Window) String() string {
+	if w.rounds > 0 {
+		return fmt.Sprintf("rounds(%d)", w.rounds)
+	}
+	return fmt.Sprintf("hold_for(%s)", w.duration)
+}
+
+func (w Window) validate() error {
+	if w.rounds <= 0 && w.duration <= 0 {
+		return fmt.Errorf("%w: window %s is empty", ErrInvalidPolicy, w)
+	}
+	return nil
+}
+
+// StableReport is report of verifier.Stable, embedded Report is report of last finished round
+type StableReport struct {
+	*Report
+	// Rounds amount of finished rounds
+	Rounds int
+	// Streak amount of consecutive matched rounds at the end of verification
+	Streak int
+	// LongestStreak amount of rounds in longest streak
+	LongestStreak int
+	// LongestStreakDuration is duration of longest streak
+	LongestStreakDuration time.Duration
+}
+
+// MarshalJSON encode report same as Report.MarshalJSON with streaks
+func (r *StableReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		jsonReport
+		Rounds                int     `json:"rounds"`
+		Streak                int     `json:"streak"`
+		LongestStreak         int     `json:"longest_streak"`
+		LongestStreakDuration float64 `json:"longest_streak_seconds"`
+	}{
+		jsonReport:            r.Report.toJSON(),
+		Rounds:                r.Rounds,
+		Streak:                r.Streak,
+		LongestStreak:         r.LongestStreak,
+		LongestStreakDuration: r.LongestStreakDuration.Seconds(),
+	})
+}
+
+// expired fail report with err because window not reached, report of last finished round kept
+func (r *StableReport) expired(err error) (*StableReport, error) {
+	last := *r.Report
+	last.Err = err
+	r.Report = &last
+	return r, err
+}
+
+// Stable repeat verification with interval between rounds until functions match policy continuously for window.
+// Any not matched round reset streak. On failure returned error of context and report with last finished round and longest streak
+func (f *verifier) Stable(window Window, interval time.Duration, p Policy, fns ...Verifier) (*StableReport, error) {
+	report := &StableReport{Report: &Report{Policy: p, Required: p.Required(len(fns))}}
+	if err := p.validate(len(fns)); err != nil {
+		report.Err = err
+		return report, err
+	}
+	if err := window.validate(); err != nil {
+		report.Err = err
+		return report, err
+	}
+	if interval <= 0 {
+		report.Err = fmt.Errorf("%w: interval must be positive", ErrInvalidPolicy)
+		return report, report.Err
+	}
+
+	var streakStart time.Time
+	for {
+		roundStart := time.Now()
+		round := f.run(p, fns...)
+		// Round interrupted by context, previous finished round explains result better
+		if f.ctx.Err() != nil {
+			if report.Rounds == 0 {
+				report.Report = round
+			}
+			return report.expired(f.ctx.Err())
+		}
+		report.Report = round
+		report.Rounds += 1
+		if round.Err != nil {
+			report.Streak = 0
+		} else {
+			if report.Streak == 0 {
+				streakStart = roundStart
+			}
+			report.Streak += 1
+			held := time.Since(streakStart)
+			if report.Streak > report.LongestStreak {
+				report.LongestStreak = report.Streak
+			}
+			if held > report.LongestStreakDuration {
+				report.LongestStreakDuration = held
+			}
+			if (window.rounds > 0 && report.Streak >= window.rounds) || (window.rounds <= 0 && held >= window.duration) {
+				return report, nil
+			}
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-f.ctx.Done():
+			timer.Stop()
+			return report.expired(f.ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
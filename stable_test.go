@@ -0,0 +1,92 @@
+package verifiers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sequence return verifier which results follow pattern, '+' is success and '-' is failure, last result repeated
+func sequence(pattern string) verifiers.Verifier {
+	var calls int32
+	return func(ctx context.Context) error {
+		index := int(atomic.AddInt32(&calls, 1)) - 1
+		if index >= len(pattern) {
+			index = len(pattern) - 1
+		}
+		if pattern[index] == '-' {
+			return someError
+		}
+		return nil
+	}
+}
+
+func TestVerifier_Stable(t *testing.T) {
+	t.Run("Return: nil - consecutive rounds matched after reset", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		report, err := verifiers.New(ctx).Stable(verifiers.Rounds(3), time.Millisecond*10, verifiers.All(), sequence("++-+++-"), succeed)
+		require.NoError(t, err)
+		assert.True(t, report.Passed())
+		assert.Equal(t, 6, report.Rounds)
+		assert.Equal(t, 3, report.Streak)
+		assert.Equal(t, 3, report.LongestStreak)
+	})
+	t.Run("Return: nil - policy hold for duration", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		startTime := time.Now()
+		report, err := verifiers.New(ctx).Stable(verifiers.HoldFor(time.Millisecond*100), time.Millisecond*20, verifiers.OneOf(), sequence("--+"), fail)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, report.LongestStreakDuration, time.Millisecond*100)
+		assert.GreaterOrEqual(t, time.Since(startTime), time.Millisecond*100)
+		assert.Greater(t, report.Streak, 1)
+	})
+	t.Run("Return: err - context expired, longest streak reported", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*300)
+		defer cancel()
+		report, err := verifiers.New(ctx).Stable(verifiers.Rounds(5), time.Millisecond*10, verifiers.All(), sequence("+++--++-"))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 3, report.LongestStreak)
+		assert.Equal(t, 0, report.Streak)
+		assert.Equal(t, someError, report.Results[0].Err)
+
+		b, err := json.Marshal(report)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, float64(3), decoded["longest_streak"])
+		assert.Equal(t, "all", decoded["policy"])
+	})
+	t.Run("Return: err - context expired after passed rounds", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*150)
+		defer cancel()
+		report, err := verifiers.New(ctx).Stable(verifiers.Rounds(100), time.Millisecond*10, verifiers.All(), sequence("+"), succeed)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, err, report.Err)
+		assert.False(t, report.Passed())
+		assert.Greater(t, report.Streak, 0)
+		assert.Equal(t, verifiers.OutcomeSuccess, report.Results[0].Outcome)
+
+		b, err := json.Marshal(report)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, false, decoded["passed"])
+	})
+	t.Run("Return: err - invalid window, interval or policy", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		_, err := v.Stable(verifiers.Rounds(0), time.Millisecond, verifiers.All(), succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrInvalidPolicy))
+		_, err = v.Stable(verifiers.HoldFor(time.Second), 0, verifiers.All(), succeed)
+		assert.True(t, errors.Is(err, verifiers.ErrInvalidPolicy))
+		_, err = v.Stable(verifiers.Rounds(1), time.Millisecond, verifiers.Exact(2), succeed)
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, err)
+	})
+}
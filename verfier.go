@@ -3,6 +3,7 @@ package verifiers
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 var (
@@ -19,12 +20,60 @@ var (
 type Verifier func(ctx context.Context) error
 
 type verifier struct {
-	ctx    context.Context
-	errCmp func(error) bool
+	ctx            context.Context
+	errCmp         func(error) bool
+	log            logger
+	tracer         Tracer
+	metrics        Metrics
+	rateLimit      *tokenBucket
+	resourceLimits []*resourceLimit
+	executor       Executor
+	partition      string
+	// notCalled receive index of function which will never be called by run, reset by verifier.with
+	notCalled func(index int)
+	// ready wait until function can be submitted to executor, error skip function. Reset by verifier.with
+	ready func(ctx context.Context, index int) error
 }
 
 type option func(v *verifier)
 
+// logger receive events of verification, see WithLogger
+type logger interface {
+	started(ctx context.Context, name string, labels []Label)
+	finished(ctx context.Context, name string, labels []Label, duration time.Duration, err error, failed bool)
+	decided(ctx context.Context, report *Report)
+}
+
+// Tracer start spans for verification and for each function inside it, see WithTracer
+type Tracer interface {
+	// Start return span and context which contains it
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation started by Tracer
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is key-value pair attached to Span, Value can be string, int or bool.
+// Labels of named verifier attached as attributes with "verifiers.label." prefix
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Metrics collect measurements of verifications, see WithMetrics
+type Metrics interface {
+	// VerificationFinished called once per verification with policy and outcome of it
+	VerificationFinished(policy, outcome string, duration time.Duration)
+	// VerifierFinished called once per function with policy and outcome(success or error) of function
+	VerifierFinished(policy, outcome string, duration time.Duration)
+	// InFlight called with 1 when function started and with -1 when function finished
+	InFlight(policy string, delta int)
+}
+
 // New return new verifier with provided context
 // If context is nil will be used context.Background()
 func New(ctx context.Context, options ...option) *verifier {
@@ -32,8 +81,9 @@ func New(ctx context.Context, options ...option) *verifier {
 		ctx = context.Background()
 	}
 	v := &verifier{
-		ctx:    ctx,
-		errCmp: defaultErrorCmp,
+		ctx:      ctx,
+		errCmp:   defaultErrorCmp,
+		executor: goroutines{},
 	}
 
 	for _, opt := range options {
@@ -49,89 +99,286 @@ func WithErrorComparator(cmp func(error) bool) option {
 	}
 }
 
+// WithTracer will start parent span for each verification and child span for each function
+func WithTracer(tracer Tracer) option {
+	return func(v *verifier) {
+		v.tracer = tracer
+	}
+}
+
+// WithMetrics will report counters, latencies and in-flight functions of each verification
+func WithMetrics(metrics Metrics) option {
+	return func(v *verifier) {
+		v.metrics = metrics
+	}
+}
+
 // All verify all function finished without error in given context timeout/deadline
 func (f *verifier) All(fns ...Verifier) error {
-	return f.Exact(len(fns), fns...)
+	return f.process(All(), fns...)
 }
 
 // AtLeast verifies is at least provided amount of functions will be finished without error in given context timeout/deadline
 func (f *verifier) AtLeast(count int, fns ...Verifier) error {
-	if count > len(fns) {
-		return ErrCountMoreThanLength
-	}
-	return f.process(len(fns)-count, false, fns...)
+	return f.process(AtLeast(count), fns...)
 }
 
 // OneOf verify at least one function finished without error in given context timeout/deadline
 func (f *verifier) OneOf(fns ...Verifier) error {
-	return f.AtLeast(1, fns...)
+	return f.process(OneOf(), fns...)
 }
 
 // OnlyOne verify exactly one function finished without error in given context timeout/deadline
 func (f *verifier) OnlyOne(fns ...Verifier) error {
-	return f.Exact(1, fns...)
+	return f.process(OnlyOne(), fns...)
 }
 
 // Exact verify exactly provided amount of functions finished without error in given context timeout/deadline
 func (f *verifier) Exact(count int, fns ...Verifier) error {
-	if count > len(fns) {
-		return ErrCountMoreThanLength
-	}
-	return f.process(len(fns)-count, true, fns...)
+	return f.process(Exact(count), fns...)
 }
 
 // NoOne verifies no one from functions finished without error in given context timeout/deadline
 func (f *verifier) NoOne(fns ...Verifier) error {
-	return f.Exact(0, fns...)
+	return f.process(NoOne(), fns...)
 }
 
-func (f *verifier) process(maxErrorCount int, exact bool, fns ...Verifier) error {
+// Run verify functions match provided policy and return detailed report of verification.
+// Returned error is same as Report.Err
+func (f *verifier) Run(p Policy, fns ...Verifier) (*Report, error) {
+	if err := p.validate(len(fns)); err != nil {
+		return &Report{Policy: p, Required: p.Required(len(fns)), Err: err}, err
+	}
+	report := f.run(p, fns...)
+	return report, report.Err
+}
+
+func (f *verifier) process(p Policy, fns ...Verifier) error {
+	if err := p.validate(len(fns)); err != nil {
+		return err
+	}
+	return f.run(p, fns...).Err
+}
+
+type response struct {
+	index    int
+	err      error
+	failed   bool
+	duration time.Duration
+	// notCalled is true if function not called, for example waiting of limits canceled
+	notCalled bool
+}
+
+func (f *verifier) run(p Policy, fns ...Verifier) (report *Report) {
+	startTime := time.Now()
+	report = &Report{
+		Policy:   p,
+		Required: p.Required(len(fns)),
+		Results:  make([]Result, len(fns)),
+	}
+	for index, fn := range fns {
+		name, labels := Describe(fn)
+		report.Results[index] = Result{Name: name, Labels: labels, Outcome: OutcomeCanceled}
+	}
+	ctx := f.ctx
+	if f.tracer != nil {
+		var span Span
+		ctx, span = f.tracer.Start(ctx, "verifiers."+p.name,
+			Attribute{Key: "verifiers.policy", Value: p.name},
+			Attribute{Key: "verifiers.required", Value: report.Required},
+			Attribute{Key: "verifiers.exact", Value: p.exact},
+			Attribute{Key: "verifiers.total", Value: len(fns)},
+		)
+		defer func() {
+			span.SetAttributes(
+				Attribute{Key: "verifiers.succeeded", Value: report.Succeeded},
+				Attribute{Key: "verifiers.failed", Value: report.Failed},
+				Attribute{Key: "verifiers.canceled", Value: report.Succeeded+report.Failed < len(fns)},
+				Attribute{Key: "verifiers.outcome", Value: report.Outcome()},
+			)
+			if report.Err != nil {
+				span.RecordError(report.Err)
+			}
+			span.End()
+		}()
+	}
+	defer func() {
+		report.Duration = time.Since(startTime)
+		if f.metrics != nil {
+			f.metrics.VerificationFinished(p.name, report.Outcome(), report.Duration)
+		}
+		if f.log != nil {
+			f.log.decided(ctx, report)
+		}
+	}()
 	if len(fns) == 0 {
-		return nil
+		return report
 	}
-	var doneWithoutError, doneWithError int
-	childrenCtx, cancel := context.WithCancel(f.ctx)
+	maxErrorCount := len(fns) - report.Required
+	childrenCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	resp := make(chan error)
-	for _, fn := range fns {
-		go func(verifier Verifier) {
-			resp <- verifier(childrenCtx)
-		}(fn)
+	// Nested plans verified with same options, see Plan.Verifier
+	childrenCtx = context.WithValue(childrenCtx, verifierKey{}, f)
+	// Nested verifications run inside task of parent function, so they can not wait for capacity taken by parent
+	var executor Executor = goroutines{}
+	if ctx.Value(executorKey{}) == nil {
+		executor = f.executor
+		childrenCtx = context.WithValue(childrenCtx, executorKey{}, true)
+	}
+	// Buffered for all functions, so routines which finished after decision not blocked forever
+	resp := make(chan response, len(fns))
+	_, direct := executor.(goroutines)
+	skip := func(index int, err error) {
+		if f.notCalled != nil {
+			f.notCalled(index)
+		}
+		resp <- response{index: index, err: err, failed: true, notCalled: true}
+	}
+	submit := func(index int, verifier Verifier) {
+		err := executor.Submit(f.partition, func() {
+			if err := childrenCtx.Err(); !direct && err != nil {
+				// Verification decided while function waited in queue of executor
+				skip(index, err)
+				return
+			}
+			r := f.call(childrenCtx, p, index, report.Results[index], verifier)
+			if r.notCalled && f.notCalled != nil {
+				f.notCalled(index)
+			}
+			resp <- r
+		})
+		if err != nil {
+			skip(index, err)
+		}
+	}
+	for index, fn := range fns {
+		index, verifier := index, fn
+		if f.ready == nil {
+			submit(index, verifier)
+			continue
+		}
+		// Waiting function not take slots of executor and limits, so it can not block functions it wait for
+		go func() {
+			if err := f.ready(childrenCtx, index); err != nil {
+				skip(index, err)
+				return
+			}
+			submit(index, verifier)
+		}()
 	}
 	for {
 		select {
-		case <-f.ctx.Done():
-			return f.ctx.Err()
-		case errInner, ok := <-resp:
-			if !ok {
-				return context.Canceled
-			}
-			if !f.errCmp(errInner) {
-				doneWithoutError += 1
+		case <-ctx.Done():
+			report.Err = ctx.Err()
+			return report
+		case r := <-resp:
+			result := &report.Results[r.index]
+			result.Err, result.Duration = r.err, r.duration
+			if !r.failed {
+				result.Outcome = OutcomeSuccess
+				report.Succeeded += 1
 			} else {
-				doneWithError += 1
+				result.Outcome = OutcomeFailure
+				report.Failed += 1
+			}
+			if f.log != nil {
+				f.log.finished(childrenCtx, result.Name, result.Labels, r.duration, r.err, r.failed)
 			}
-			if !exact {
-				if doneWithoutError == len(fns)-maxErrorCount {
-					return nil
+			if !p.exact {
+				if report.Succeeded == len(fns)-maxErrorCount {
+					return report
 				}
-				if doneWithError > maxErrorCount {
-					return ErrMaxAmountOfError
+				if report.Failed > maxErrorCount {
+					report.Err = ErrMaxAmountOfError
+					return report
 				}
 				continue
 			}
 
-			if doneWithError > maxErrorCount {
-				return ErrMaxAmountOfError
+			if report.Failed > maxErrorCount {
+				report.Err = ErrMaxAmountOfError
+				return report
 			}
 
-			if doneWithoutError > len(fns)-maxErrorCount {
-				return ErrMaxAmountOfFinished
+			if report.Succeeded > len(fns)-maxErrorCount {
+				report.Err = ErrMaxAmountOfFinished
+				return report
 			}
 
-			if doneWithError+doneWithoutError == len(fns) {
-				return nil
+			if report.Failed+report.Succeeded == len(fns) {
+				return report
 			}
 		}
 	}
 }
+
+// call execute single function and report it to logger, tracer and metrics
+func (f *verifier) call(ctx context.Context, p Policy, index int, result Result, fn Verifier) response {
+	// Waiting canceled with children context once verification decided
+	release, err := f.acquire(ctx, result.Labels)
+	if err != nil {
+		return response{index: index, err: err, failed: true, notCalled: true}
+	}
+	defer release()
+	if f.log != nil {
+		f.log.started(ctx, result.Name, result.Labels)
+	}
+	var span Span
+	if f.tracer != nil {
+		attrs := make([]Attribute, 0, len(result.Labels)+1)
+		attrs = append(attrs, Attribute{Key: "verifiers.name", Value: result.Name})
+		for _, label := range result.Labels {
+			attrs = append(attrs, Attribute{Key: "verifiers.label." + label.Key, Value: label.Value})
+		}
+		ctx, span = f.tracer.Start(ctx, "verifiers.verifier", attrs...)
+	}
+	if f.metrics != nil {
+		f.metrics.InFlight(p.name, 1)
+	}
+	startTime := time.Now()
+	err = fn(ctx)
+	r := response{index: index, err: err, failed: f.failed(err), duration: time.Since(startTime)}
+	if f.metrics != nil {
+		f.metrics.InFlight(p.name, -1)
+		result := "success"
+		if r.failed {
+			result = "error"
+		}
+		f.metrics.VerifierFinished(p.name, result, r.duration)
+	}
+	if span != nil {
+		span.SetAttributes(
+			Attribute{Key: "verifiers.failed", Value: r.failed},
+			Attribute{Key: "verifiers.canceled", Value: ctx.Err() != nil},
+		)
+		if err != nil {
+			span.RecordError(&VerifierError{Name: result.Name, Labels: result.Labels, Err: err})
+		}
+		span.End()
+	}
+	return r
+}
+
+// failed return true if function finished with error, errors of not called functions always failed
+func (f *verifier) failed(err error) bool {
+	return f.errCmp(err) || errors.Is(err, ErrSkipped) || errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrRejected)
+}
+
+// outcome return short name of verification result
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, ErrMaxAmountOfError):
+		return "max_amount_of_error"
+	case errors.Is(err, ErrMaxAmountOfFinished):
+		return "max_amount_of_finished"
+	case errors.Is(err, ErrCountMoreThanLength):
+		return "count_more_than_length"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return "error"
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/PxyUp/verifiers"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
@@ -790,3 +791,187 @@ func TestWithErrorComparator(t *testing.T) {
 		))
 	})
 }
+
+type recordedSpan struct {
+	mu     *sync.Mutex
+	name   string
+	parent *recordedSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...verifiers.Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *recordedSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+type spanKey struct{}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...verifiers.Attribute) (context.Context, verifiers.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	span := &recordedSpan{mu: &sync.Mutex{}, name: name, parent: parent, attrs: map[string]interface{}{}}
+	for _, attr := range attrs {
+		span.attrs[attr.Key] = attr.Value
+	}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestWithTracer(t *testing.T) {
+	t.Run("Trace: parent and child spans", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		v := verifiers.New(context.Background(), verifiers.WithTracer(tracer))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(
+			func(ctx context.Context) error {
+				return someError
+			},
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		))
+		time.Sleep(time.Millisecond * 100)
+		tracer.mu.Lock()
+		defer tracer.mu.Unlock()
+		assert.Len(t, tracer.spans, 3)
+		parent := tracer.spans[0]
+		assert.Equal(t, "verifiers.all", parent.name)
+		assert.Nil(t, parent.parent)
+		assert.True(t, parent.ended)
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, parent.err)
+		assert.Equal(t, "all", parent.attrs["verifiers.policy"])
+		assert.Equal(t, 2, parent.attrs["verifiers.required"])
+		assert.Equal(t, 1, parent.attrs["verifiers.failed"])
+		assert.Equal(t, true, parent.attrs["verifiers.canceled"])
+		assert.Equal(t, "max_amount_of_error", parent.attrs["verifiers.outcome"])
+		for _, child := range tracer.spans[1:] {
+			child.mu.Lock()
+			assert.Equal(t, "verifiers.verifier", child.name)
+			assert.Equal(t, parent, child.parent)
+			assert.True(t, child.ended)
+			assert.Equal(t, true, child.attrs["verifiers.failed"])
+			child.mu.Unlock()
+		}
+	})
+}
+
+type recordingMetrics struct {
+	mu            sync.Mutex
+	verifications []string
+	verifiers     []string
+	inFlight      int
+}
+
+func (m *recordingMetrics) VerificationFinished(policy, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifications = append(m.verifications, policy+":"+outcome)
+}
+
+func (m *recordingMetrics) VerifierFinished(policy, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifiers = append(m.verifiers, policy+":"+outcome)
+}
+
+func (m *recordingMetrics) InFlight(policy string, delta int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight += delta
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Run("Report: verification and verifiers", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		v := verifiers.New(context.Background(), verifiers.WithMetrics(metrics))
+		assert.Equal(t, verifiers.ErrMaxAmountOfFinished, v.NoOne(
+			func(ctx context.Context) error {
+				return nil
+			},
+		))
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		assert.Equal(t, []string{"no_one:max_amount_of_finished"}, metrics.verifications)
+		assert.Equal(t, []string{"no_one:success"}, metrics.verifiers)
+		assert.Equal(t, 0, metrics.inFlight)
+	})
+}
+
+func TestVerifier_Run(t *testing.T) {
+	t.Run("Report: results in order of functions", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		report, err := v.Run(verifiers.AtLeast(1),
+			verifiers.Named("slow", func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			verifiers.Named("failed", func(ctx context.Context) error {
+				return someError
+			}),
+			verifiers.Named("fast", func(ctx context.Context) error {
+				time.Sleep(time.Millisecond * 50)
+				return nil
+			}),
+		)
+		assert.NoError(t, err)
+		assert.True(t, report.Passed())
+		assert.Equal(t, "success", report.Outcome())
+		assert.Equal(t, 1, report.Required)
+		assert.Equal(t, 1, report.Succeeded)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, "at_least(1)", report.Policy.String())
+		assert.Len(t, report.Results, 3)
+		assert.Equal(t, "slow", report.Results[0].Name)
+		assert.Equal(t, verifiers.OutcomeCanceled, report.Results[0].Outcome)
+		assert.Equal(t, verifiers.OutcomeFailure, report.Results[1].Outcome)
+		assert.Equal(t, someError, report.Results[1].Err)
+		assert.Equal(t, verifiers.OutcomeSuccess, report.Results[2].Outcome)
+		assert.True(t, report.Results[2].Duration >= time.Millisecond*50)
+	})
+	t.Run("Report: all policy require all functions", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		report, err := v.Run(verifiers.All(),
+			func(ctx context.Context) error {
+				return nil
+			},
+			func(ctx context.Context) error {
+				return someError
+			},
+		)
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, err)
+		assert.Equal(t, 2, report.Required)
+		assert.False(t, report.Passed())
+		assert.Equal(t, "max_amount_of_error", report.Outcome())
+	})
+	t.Run("Report: invalid policy", func(t *testing.T) {
+		v := verifiers.New(context.Background())
+		report, err := v.Run(verifiers.Exact(2), func(ctx context.Context) error {
+			return nil
+		})
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, err)
+		assert.Equal(t, verifiers.ErrCountMoreThanLength, report.Err)
+	})
+}
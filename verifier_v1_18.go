@@ -19,3 +19,15 @@ func FromArray[T any](arr []T, cmp func(context.Context, T) error) []Verifier {
 
 	return fns
 }
+
+// Only for v1.18 +
+// FromCheckArray generate Verifier from array of checks with concrete type
+func FromCheckArray[T Check](arr []T) []Verifier {
+	fns := make([]Verifier, len(arr))
+
+	for index := range arr {
+		fns[index] = FromCheck(arr[index])
+	}
+
+	return fns
+}
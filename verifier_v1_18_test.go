@@ -134,3 +134,17 @@ func TestFromArray(t *testing.T) {
 		assert.Equal(t, verifiers.ErrMaxAmountOfFinished, v.OnlyOne(fns...))
 	})
 }
+
+func TestFromCheckArray(t *testing.T) {
+	checks := []*thresholdCheck{
+		{name: "disk", value: 10, threshold: 5},
+		{name: "memory", value: 1, threshold: 5},
+	}
+	fns := append(verifiers.FromCheckArray(checks), verifiers.FromArray([]int{1, 2}, func(ctx context.Context, value int) error {
+		return nil
+	})...)
+	assert.Len(t, fns, 4)
+	assert.Equal(t, "memory", fns[1].Name())
+	v := verifiers.New(context.Background())
+	assert.NoError(t, v.Exact(3, fns...))
+}
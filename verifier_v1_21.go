@@ -0,0 +1,116 @@
+//go:build go1.21
+// +build go1.21
+
+package verifiers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LogLevels configure levels of records which produced by verifier with WithLogger option
+type LogLevels struct {
+	// Start used when verifier function started
+	Start slog.Level
+	// Success used when verifier function finished without error
+	Success slog.Level
+	// Failure used when verifier function finished with error
+	Failure slog.Level
+	// Decision used for final decision of verification
+	Decision slog.Level
+}
+
+// DefaultLogLevels used by WithLogger if levels not provided by WithLogLevels
+var DefaultLogLevels = LogLevels{
+	Start:    slog.LevelDebug,
+	Success:  slog.LevelDebug,
+	Failure:  slog.LevelInfo,
+	Decision: slog.LevelInfo,
+}
+
+// Only for v1.21 +
+// WithLogger will log start/finish of each function and final decision of verification
+func WithLogger(logger *slog.Logger) option {
+	return func(v *verifier) {
+		slogFrom(v).logger = logger
+	}
+}
+
+// Only for v1.21 +
+// WithLogLevels will modify levels which used by WithLogger
+func WithLogLevels(levels LogLevels) option {
+	return func(v *verifier) {
+		slogFrom(v).levels = levels
+	}
+}
+
+func slogFrom(v *verifier) *slogLogger {
+	if l, ok := v.log.(*slogLogger); ok {
+		return l
+	}
+	l := &slogLogger{levels: DefaultLogLevels}
+	v.log = l
+	return l
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+	levels LogLevels
+}
+
+func (l *slogLogger) started(ctx context.Context, name string, labels []Label) {
+	if l.logger == nil {
+		return
+	}
+	l.logger.LogAttrs(ctx, l.levels.Start, "verifier started", slog.String("name", name), labelsAttr(labels))
+}
+
+func (l *slogLogger) finished(ctx context.Context, name string, labels []Label, duration time.Duration, err error, failed bool) {
+	if l.logger == nil {
+		return
+	}
+	level, result := l.levels.Success, "success"
+	if failed {
+		level, result = l.levels.Failure, "error"
+	}
+	attrs := []slog.Attr{
+		slog.String("name", name),
+		labelsAttr(labels),
+		slog.Duration("duration", duration),
+		slog.String("outcome", result),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, "verifier finished", attrs...)
+}
+
+func (l *slogLogger) decided(ctx context.Context, report *Report) {
+	if l.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("policy", report.Policy.Name()),
+		slog.Int("required", report.Required),
+		slog.Bool("exact", report.Policy.Exact()),
+		slog.Int("total", len(report.Results)),
+		slog.Int("succeeded", report.Succeeded),
+		slog.Int("failed", report.Failed),
+		slog.String("outcome", report.Outcome()),
+		slog.Duration("duration", report.Duration),
+	}
+	if report.Err != nil {
+		attrs = append(attrs, slog.String("error", report.Err.Error()))
+	}
+	l.logger.LogAttrs(ctx, l.levels.Decision, "verification decided", attrs...)
+}
+
+// labelsAttr return group of labels, empty group omitted by handlers
+func labelsAttr(labels []Label) slog.Attr {
+	attrs := make([]interface{}, len(labels))
+	for index, label := range labels {
+		attrs[index] = slog.String(label.Key, label.Value)
+	}
+	return slog.Group("labels", attrs...)
+}
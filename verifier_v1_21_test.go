@@ -0,0 +1,107 @@
+//go:build go1.21
+// +build go1.21
+
+package verifiers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) records(t *testing.T) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var records []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(b.buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		record := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(line, &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("Log: start, finish and decision", func(t *testing.T) {
+		buf := &logBuffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		v := verifiers.New(context.Background(), verifiers.WithLogger(logger))
+		assert.NoError(t, v.AtLeast(1,
+			verifiers.Named("db", func(ctx context.Context) error {
+				return nil
+			}),
+			verifiers.Named("cache", func(ctx context.Context) error {
+				return someError
+			}, verifiers.Label{Key: "zone", Value: "a"}),
+		))
+		records := buf.records(t)
+		var started, finished int
+		var decision map[string]interface{}
+		for _, record := range records {
+			switch record["msg"] {
+			case "verifier started":
+				started += 1
+			case "verifier finished":
+				finished += 1
+				assert.Contains(t, record, "duration")
+				if record["outcome"] == "error" {
+					assert.Equal(t, "cache", record["name"])
+					assert.Equal(t, map[string]interface{}{"zone": "a"}, record["labels"])
+					assert.Equal(t, someError.Error(), record["error"])
+					assert.Equal(t, "INFO", record["level"])
+				}
+			case "verification decided":
+				decision = record
+			}
+		}
+		assert.GreaterOrEqual(t, started, 1)
+		assert.GreaterOrEqual(t, finished, 1)
+		require.NotNil(t, decision)
+		assert.Equal(t, "at_least", decision["policy"])
+		assert.Equal(t, float64(1), decision["required"])
+		assert.Equal(t, float64(2), decision["total"])
+		assert.Equal(t, "success", decision["outcome"])
+	})
+	t.Run("Log: decision with custom levels", func(t *testing.T) {
+		buf := &logBuffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		v := verifiers.New(context.Background(), verifiers.WithLogLevels(verifiers.LogLevels{
+			Start:    slog.LevelDebug,
+			Success:  slog.LevelDebug,
+			Failure:  slog.LevelDebug,
+			Decision: slog.LevelError,
+		}), verifiers.WithLogger(logger))
+		assert.Equal(t, verifiers.ErrMaxAmountOfError, v.All(
+			func(ctx context.Context) error {
+				return someError
+			},
+		))
+		records := buf.records(t)
+		require.Len(t, records, 1)
+		assert.Equal(t, "verification decided", records[0]["msg"])
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, "all", records[0]["policy"])
+		assert.Equal(t, "max_amount_of_error", records[0]["outcome"])
+		assert.Equal(t, float64(1), records[0]["failed"])
+	})
+}
@@ -0,0 +1,43 @@
+package verifiers
+
+import (
+	"context"
+	"time"
+)
+
+// Timeout return verifier which call fn with context limited by timeout, name and labels of fn kept
+func Timeout(fn Verifier, timeout time.Duration) Verifier {
+	name, labels := Describe(fn)
+	return Named(name, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return fn(ctx)
+	}, labels...)
+}
+
+// Retry return verifier which call fn up to attempts times with delay between calls until it finished without error.
+// Retry stopped if context done, fn called at least once. Name and labels of fn kept
+func Retry(fn Verifier, attempts int, delay time.Duration) Verifier {
+	if attempts < 1 {
+		attempts = 1
+	}
+	name, labels := Describe(fn)
+	return Named(name, func(ctx context.Context) error {
+		var err error
+		for attempt := 0; attempt < attempts; attempt++ {
+			if attempt > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+			}
+			if err = fn(ctx); err == nil {
+				return nil
+			}
+		}
+		return err
+	}, labels...)
+}
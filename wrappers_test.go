@@ -0,0 +1,60 @@
+package verifiers_test
+
+import (
+	"context"
+	"github.com/PxyUp/verifiers"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	fn := verifiers.Timeout(verifiers.Named("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), time.Millisecond*10)
+	assert.Equal(t, "slow", fn.Name())
+	assert.Equal(t, context.DeadlineExceeded, fn(context.Background()))
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("Return: nil - succeed after retries", func(t *testing.T) {
+		calls := 0
+		fn := verifiers.Retry(verifiers.Named("flaky", func(ctx context.Context) error {
+			calls += 1
+			if calls < 3 {
+				return someError
+			}
+			return nil
+		}), 3, time.Millisecond)
+		assert.Equal(t, "flaky", fn.Name())
+		assert.NoError(t, fn(context.Background()))
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("Return: err - attempts exceeded", func(t *testing.T) {
+		calls := 0
+		fn := verifiers.Retry(func(ctx context.Context) error {
+			calls += 1
+			return someError
+		}, 2, time.Millisecond)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("Return: err - not positive attempts call function once", func(t *testing.T) {
+		calls := 0
+		fn := verifiers.Retry(func(ctx context.Context) error {
+			calls += 1
+			return someError
+		}, 0, time.Millisecond)
+		assert.Equal(t, someError, fn(context.Background()))
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("Return: err - context done while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		fn := verifiers.Retry(fail, 5, time.Second)
+		startTime := time.Now()
+		assert.Equal(t, someError, fn(ctx))
+		assert.True(t, time.Since(startTime) < time.Second)
+	})
+}